@@ -7,6 +7,6 @@ import (
 
 func main() {
 	if err := command.GetRootCmd().Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(command.ExitCode(err))
 	}
 }
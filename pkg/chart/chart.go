@@ -0,0 +1,100 @@
+// Package chart renders bar charts and sparklines with Unicode block characters for the terminal
+package chart
+
+import (
+	"fmt"
+	"io"
+	v2 "ledger/pkg/ledger/v2"
+	"strings"
+	"unicode/utf8"
+)
+
+// sparkTicks are the block characters of a sparkline, from lowest to highest
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// barEighths are the partial blocks drawing the last cell of a bar, in eighths of a cell
+var barEighths = []rune(" ▏▎▍▌▋▊▉")
+
+// MinBarWidth is the smallest width of the bar area, even if the chart then exceeds the requested width
+const MinBarWidth = 10
+
+// Bar is a labeled value of a bar chart
+type Bar struct {
+	Label string
+	Value int
+	// Text is the formatted value printed after the bar
+	Text string
+}
+
+// Sparkline returns a one-line chart of the values, scaled between their minimum and maximum
+func Sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		tick := len(sparkTicks) / 2
+		if hi > lo {
+			tick = int(float64(v-lo) / float64(hi-lo) * float64(len(sparkTicks)-1))
+		}
+		sb.WriteRune(sparkTicks[tick])
+	}
+	return sb.String()
+}
+
+// RenderBars writes a horizontal bar chart fitting the given width in columns.
+// Bars are scaled to the largest magnitude, so negative values are drawn by their size
+// and told apart by their text.
+func RenderBars(w io.Writer, bars []Bar, width int) error {
+	labelWidth, textWidth, maxValue := 0, 0, 0
+	for _, bar := range bars {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(bar.Label))
+		textWidth = max(textWidth, utf8.RuneCountInString(bar.Text))
+		maxValue = max(maxValue, v2.Abs(bar.Value))
+	}
+
+	// label, " │", bar, " ", text
+	barWidth := max(width-labelWidth-textWidth-3, MinBarWidth)
+
+	for _, bar := range bars {
+		line := fmt.Sprintf("%s │%s %s",
+			pad(bar.Label, labelWidth),
+			pad(barString(v2.Abs(bar.Value), maxValue, barWidth), barWidth),
+			bar.Text,
+		)
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// barString returns a bar of the value scaled to width cells at maxValue, in eighths of a cell
+func barString(value, maxValue, width int) string {
+	if maxValue == 0 || value == 0 {
+		return ""
+	}
+
+	eighths := int(float64(value) / float64(maxValue) * float64(width*8))
+	if eighths == 0 {
+		// Keep small non-zero values visible
+		eighths = 1
+	}
+
+	bar := strings.Repeat("█", eighths/8)
+	if eighths%8 > 0 {
+		bar += string(barEighths[eighths%8])
+	}
+	return bar
+}
+
+// pad right-pads s with spaces to width runes
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-utf8.RuneCountInString(s), 0))
+}
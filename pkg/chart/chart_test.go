@@ -0,0 +1,60 @@
+package chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   string
+	}{
+		{name: "empty", values: nil, want: ""},
+		{name: "rising", values: []int{0, 1, 2, 3, 4, 5, 6, 7}, want: "▁▂▃▄▅▆▇█"},
+		{name: "negative values", values: []int{-10, 0, 10}, want: "▁▄█"},
+		{name: "flat", values: []int{5, 5, 5}, want: "▅▅▅"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Sparkline(tt.values))
+		})
+	}
+}
+
+func TestRenderBars(t *testing.T) {
+	bars := []Bar{
+		{Label: "2025-01", Value: 100, Text: "100.00"},
+		{Label: "2025-02", Value: -50, Text: "-50.00"},
+		{Label: "2025-03", Value: 1, Text: "1.00"},
+		{Label: "2025-04", Value: 0, Text: "0.00"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderBars(&buf, bars, 40))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+
+	// 7 label + 2 separator + 23 bar + 1 space + 6 text = 39 columns, within the requested width
+	assert.Equal(t, "2025-01 │"+strings.Repeat("█", 24)+" 100.00", lines[0])
+	assert.Equal(t, "2025-02 │"+strings.Repeat("█", 12)+strings.Repeat(" ", 12)+" -50.00", lines[1])
+	assert.Equal(t, "2025-03 │▏"+strings.Repeat(" ", 23)+" 1.00", lines[2])
+	assert.Equal(t, "2025-04 │"+strings.Repeat(" ", 24)+" 0.00", lines[3])
+	for _, line := range lines {
+		assert.LessOrEqual(t, utf8.RuneCountInString(line), 40)
+	}
+}
+
+func TestRenderBars_MinWidth(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, RenderBars(&buf, []Bar{{Label: "a", Value: 1, Text: "1"}}, 5))
+	assert.Equal(t, "a │"+strings.Repeat("█", MinBarWidth)+" 1\n", buf.String())
+}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// v2AccountTable computes the balances and flows of every account per month of the period.
+// With an account name, the history of that single account is computed instead.
+// The history is computed from the whole ledger, so opened and closed accounts
+// are marked correctly at the edges of the period.
+func v2AccountTable(history map[string][]v2.AccountMonth, period v2.Ledger, account string) (report.Table, error) {
+	var t report.Table
+
+	if account != "" {
+		months, ok := history[account]
+		if !ok {
+			return report.Table{}, fmt.Errorf("account %s not found in ledger", account)
+		}
+
+		t.Title = account
+		t.AddNumberColumn("Year")
+		t.AddNumberColumn("Month")
+		addAccountColumns(&t)
+
+		prevYear := 0
+		for _, m := range lo.Filter(months, func(m v2.AccountMonth, _ int) bool { return inPeriod(period, m) }) {
+			t.Rows = append(t.Rows, report.Row{
+				Cells:     append([]any{m.Year, m.Month}, accountCells(m)...),
+				Separator: m.Year != prevYear,
+			})
+			prevYear = m.Year
+		}
+
+		return t, nil
+	}
+
+	type namedMonth struct {
+		name string
+		v2.AccountMonth
+	}
+
+	var months []namedMonth
+	for name, accountMonths := range history {
+		for _, m := range accountMonths {
+			if inPeriod(period, m) {
+				months = append(months, namedMonth{name: name, AccountMonth: m})
+			}
+		}
+	}
+	sort.Slice(months, func(i, j int) bool {
+		a, b := months[i], months[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		return a.name < b.name
+	})
+
+	t.AddNumberColumn("Year")
+	t.AddNumberColumn("Month")
+	t.AddColumn("Account")
+	addAccountColumns(&t)
+
+	for i, m := range months {
+		t.Rows = append(t.Rows, report.Row{
+			Cells:     append([]any{m.Year, m.Month, m.name}, accountCells(m.AccountMonth)...),
+			Separator: i == 0 || m.Year != months[i-1].Year || m.Month != months[i-1].Month,
+		})
+	}
+
+	return t, nil
+}
+
+// addAccountColumns appends the balance, flow and status columns of an account month
+func addAccountColumns(t *report.Table) {
+	t.AddNumberColumn("Opening")
+	t.AddNumberColumn("Inflows")
+	t.AddNumberColumn("Outflows")
+	t.AddNumberColumn("Transfers")
+	t.AddNumberColumn("Closing")
+	t.AddColumn("Status")
+}
+
+// accountCells returns the balance, flow and status cells of an account month
+func accountCells(m v2.AccountMonth) []any {
+	var status []string
+	if m.Opened {
+		status = append(status, "opened")
+	}
+	if m.Closed {
+		status = append(status, "closed")
+	}
+
+	return []any{
+		report.Amount(m.Account.OpeningBalance),
+		report.Amount(m.Account.Income()),
+		report.Amount(m.Account.Expenses()),
+		report.Amount(m.Account.InternalEntriesSum()),
+		report.Amount(m.Account.ClosingBalance),
+		strings.Join(status, ", "),
+	}
+}
+
+// inPeriod reports whether the month of an account is part of the reported ledger
+func inPeriod(period v2.Ledger, m v2.AccountMonth) bool {
+	_, ok := period.Years[m.Year].Months[m.Month]
+	return ok
+}
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+func getAnomaliesCmd() *cobra.Command {
+	var format string
+	var opts v2.AnomalyOptions
+	var minAmount string
+	var kinds []string
+	var period periodFlags
+
+	cmd := &cobra.Command{
+		Use:   "anomalies <file>",
+		Short: "Flag unusual months, entries and balance swings of OLF v2.0 file",
+		Long: `Flag unusual months, entries and balance swings of OLF v2.0 file.
+
+Every month is compared against the preceding --window months of the ledger,
+once at least --min-history of them exist. Flagged are:
+- expenses: month expenses deviating from the trailing median by more than
+  --threshold percent
+- tag:      a tag's monthly total (excluding internal transfers) deviating
+  likewise; tags absent from a month count as zero
+- entry:    single entries more than --factor times the median size of the
+  entries of their tag
+- account:  account balance changes farther from their median than --factor
+  times the typical distance
+Deviations smaller than --min-amount (in currency units) are ignored.
+
+Months before the selected period are still used as history, so
+--last 1m reviews the latest month against the months before it.
+
+Examples:
+  ledger anomalies ledger.yaml                       # Whole ledger
+  ledger anomalies ledger.yaml --last 1m             # Latest month only
+  ledger anomalies ledger.yaml --threshold 100 --min-amount 50
+  ledger anomalies ledger.yaml --kind entry,tag      # Entries and tags only`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			reportFormat, err := parseReportFormat(format)
+			if err != nil {
+				return err
+			}
+			allKinds := []v2.AnomalyKind{v2.AnomalyExpenses, v2.AnomalyTag, v2.AnomalyEntry, v2.AnomalyAccount}
+			for _, kind := range kinds {
+				if !lo.Contains(allKinds, v2.AnomalyKind(kind)) {
+					return fmt.Errorf("unsupported anomaly kind %q, expected one of %v", kind, allKinds)
+				}
+			}
+			if opts.Window < 0 || opts.MinHistory < 0 || opts.Threshold < 0 || opts.Factor < 0 {
+				return fmt.Errorf("--window, --min-history, --threshold and --factor must not be negative")
+			}
+			cmd.SilenceUsage = true
+
+			ledger, filtered, currency, err := loadReport(cmd, path, &period)
+			if err != nil {
+				return err
+			}
+
+			if minAmount != "" {
+				if opts.MinAmount, err = currency.ParseAmount(minAmount); err != nil {
+					return fmt.Errorf("invalid --min-amount: %w", err)
+				}
+			}
+
+			anomalies := lo.Filter(ledger.Anomalies(opts), func(a v2.Anomaly, _ int) bool {
+				_, inPeriod := filtered.Years[a.Year].Months[a.Month]
+				return inPeriod && (len(kinds) == 0 || lo.Contains(kinds, string(a.Kind)))
+			})
+
+			return renderReport(cmd, anomalyTable(anomalies), reportFormat, currency)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Window, "window", v2.DefaultAnomalyWindow, "Number of preceding months compared against")
+	cmd.Flags().IntVar(&opts.MinHistory, "min-history", v2.DefaultAnomalyMinHistory, "Number of preceding months, or entries of a tag, needed before flagging")
+	cmd.Flags().Float64Var(&opts.Threshold, "threshold", v2.DefaultAnomalyThreshold, "Deviation from the trailing median in percent flagged for expenses and tags")
+	cmd.Flags().Float64Var(&opts.Factor, "factor", v2.DefaultAnomalyFactor, "How many times larger than typical entries and balance changes must be to be flagged")
+	cmd.Flags().StringVar(&minAmount, "min-amount", "", "Smallest deviation flagged, in currency units")
+	cmd.Flags().StringSliceVar(&kinds, "kind", nil, "Only show these kinds: expenses, tag, entry, account")
+	addPeriodFlags(cmd, &period)
+	addReportFormatFlag(cmd, &format)
+
+	return cmd
+}
+
+// anomalyTable lists the anomalies with their typical and actual values
+func anomalyTable(anomalies []v2.Anomaly) report.Table {
+	t := report.Table{Title: fmt.Sprintf("Anomalies (%d)", len(anomalies))}
+	t.AddColumn("Month")
+	t.AddColumn("Kind")
+	t.AddColumn("Tag")
+	t.AddColumn("Account")
+	t.AddColumn("Note")
+	addDeltaColumns(&t, "Typical", "Actual")
+
+	prev := v2.YearMonth{}
+	for _, a := range anomalies {
+		tag := a.Tag
+		if a.Kind == v2.AnomalyTag || a.Kind == v2.AnomalyEntry {
+			tag = v2.TagLabel(a.Tag)
+		}
+		cells := append([]any{a.String(), string(a.Kind), tag, a.Account, a.Note}, deltaCells(a.Delta)...)
+		t.Rows = append(t.Rows, report.Row{Cells: cells, Separator: !prev.IsZero() && a.YearMonth != prev})
+		prev = a.YearMonth
+	}
+
+	return t
+}
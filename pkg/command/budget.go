@@ -0,0 +1,178 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+
+	"github.com/spf13/cobra"
+)
+
+// Status cells of the budget report
+const (
+	budgetOver    = "OVER"
+	budgetOverYTD = "over YTD"
+)
+
+func getBudgetCmd() *cobra.Command {
+	var format string
+	var month string
+
+	cmd := &cobra.Command{
+		Use:   "budget <file>",
+		Short: "Compare spending per tag against the budgets of OLF v2.0 file",
+		Long: `Compare spending per tag against the budgets of OLF v2.0 file.
+
+Budgets are defined in the optional top-level "budgets" section of the ledger,
+per tag and per month or per year. A budget applies from its "from" month
+onward until a later budget of the same tag and period replaces it:
+
+  budgets:
+    - tag: Food
+      amount: 400
+    - tag: Food
+      amount: 450
+      from: 2025-04
+    - tag: Travel
+      amount: 2000
+      period: year
+
+Spending is the negated total of the tag's entries, excluding internal
+transfers, so refunds reduce it. For the selected month (default: the latest
+month of the ledger) the report shows:
+- Monthly budgets: budget, spent and remaining for the month and the year to date
+- Yearly budgets: budget, spent and remaining for the year to date
+Overspent budgets are flagged in the Status column.
+
+Examples:
+  ledger budget ledger.yaml                  # Latest month
+  ledger budget ledger.yaml --month 2025-03  # March 2025
+  ledger budget ledger.yaml -f csv           # Export as CSV`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			reportFormat, err := parseReportFormat(format)
+			if err != nil {
+				return err
+			}
+			var ym v2.YearMonth
+			if month != "" {
+				if ym, err = v2.ParseYearMonth(month); err != nil {
+					return fmt.Errorf("invalid --month: %w", err)
+				}
+			}
+			cmd.SilenceUsage = true
+
+			ledger, _, currency, err := loadReport(cmd, path, nil)
+			if err != nil {
+				return err
+			}
+
+			if len(ledger.Budgets) == 0 {
+				return fmt.Errorf("no budgets defined in the ledger")
+			}
+
+			months := ledger.GetMonths()
+			if ym.IsZero() && len(months) > 0 {
+				ym = months[len(months)-1]
+			}
+			if _, ok := ledger.Years[ym.Year].Months[ym.Month]; !ok {
+				return fmt.Errorf("month %s not found in ledger", ym)
+			}
+
+			return renderReports(cmd, budgetTables(ledger.BudgetReport(ym)), reportFormat, currency)
+		},
+	}
+
+	cmd.Flags().StringVar(&month, "month", "", "Month to report, in YYYY-MM format (default the latest month of the ledger)")
+	addReportFormatFlag(cmd, &format)
+
+	return cmd
+}
+
+// budgetTables computes the monthly and, if any, the yearly budget tables of the report
+func budgetTables(budgets v2.BudgetReport) []report.Table {
+	monthly := report.Table{Title: fmt.Sprintf("Monthly budgets: %s", budgets.YearMonth)}
+	monthly.AddColumn("Tag")
+	addBudgetColumns(&monthly, "")
+	addBudgetColumns(&monthly, "YTD ")
+	monthly.AddColumn("Status")
+
+	var month, ytd v2.BudgetLine
+	for _, b := range budgets.Monthly {
+		cells := append([]any{b.Tag}, budgetCells(b.Month)...)
+		cells = append(cells, budgetCells(b.YearToDate)...)
+		monthly.AddRow(append(cells, budgetStatus(b))...)
+
+		month = addBudgetLines(month, b.Month)
+		ytd = addBudgetLines(ytd, b.YearToDate)
+	}
+
+	footer := append([]any{"Total"}, budgetCells(month)...)
+	footer = append(footer, budgetCells(ytd)...)
+	monthly.SetFooter(append(footer, nil)...)
+
+	if len(budgets.Yearly) == 0 {
+		return []report.Table{monthly}
+	}
+
+	yearly := report.Table{Title: fmt.Sprintf("Yearly budgets: %d up to %s", budgets.Year, budgets.YearMonth)}
+	yearly.AddColumn("Tag")
+	yearly.AddNumberColumn("Spent " + budgets.YearMonth.String())
+	addBudgetColumns(&yearly, "YTD ")
+	yearly.AddColumn("Status")
+
+	var spent int
+	ytd = v2.BudgetLine{}
+	for _, b := range budgets.Yearly {
+		cells := append([]any{b.Tag, report.Amount(b.Month.Spent)}, budgetCells(b.YearToDate)...)
+		yearly.AddRow(append(cells, budgetStatus(b))...)
+
+		spent += b.Month.Spent
+		ytd = addBudgetLines(ytd, b.YearToDate)
+	}
+
+	footer = append([]any{"Total", report.Amount(spent)}, budgetCells(ytd)...)
+	yearly.SetFooter(append(footer, nil)...)
+
+	return []report.Table{monthly, yearly}
+}
+
+// addBudgetColumns appends the budget, spent, remaining and used columns
+func addBudgetColumns(t *report.Table, prefix string) {
+	t.AddNumberColumn(prefix + "Budget")
+	t.AddNumberColumn(prefix + "Spent")
+	t.AddNumberColumn(prefix + "Remaining")
+	t.AddNumberColumn(prefix + "Used")
+}
+
+// budgetCells returns the budget, spent, remaining and used cells
+func budgetCells(b v2.BudgetLine) []any {
+	return []any{
+		report.Amount(b.Budget),
+		report.Amount(b.Spent),
+		report.Amount(b.Remaining()),
+		report.OptionalPercent(b.Used()),
+	}
+}
+
+// budgetStatus flags a budget overspent in the month, or else over the year to date
+func budgetStatus(b v2.TagBudget) any {
+	switch {
+	case b.Month.Budget != 0 && b.Month.Overspent():
+		return budgetOver
+	case b.YearToDate.Overspent():
+		if b.Month.Budget == 0 {
+			// Yearly budgets have no monthly figure to flag
+			return budgetOver
+		}
+		return budgetOverYTD
+	}
+	return nil
+}
+
+// addBudgetLines sums two budget lines
+func addBudgetLines(a, b v2.BudgetLine) v2.BudgetLine {
+	return v2.BudgetLine{Budget: a.Budget + b.Budget, Spent: a.Spent + b.Spent}
+}
@@ -0,0 +1,178 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"ledger/pkg/chart"
+	v2 "ledger/pkg/ledger/v2"
+	"os"
+	"strconv"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// defaultChartWidth is the chart width when neither --width, a terminal nor COLUMNS gives one
+const defaultChartWidth = 80
+
+// Chart names accepted by --type
+const (
+	chartNetWorth = "networth"
+	chartExpenses = "expenses"
+	chartTags     = "tags"
+)
+
+var chartTypes = []string{chartNetWorth, chartExpenses, chartTags}
+
+func getChartCmd() *cobra.Command {
+	var types []string
+	var width int
+	var period periodFlags
+
+	cmd := &cobra.Command{
+		Use:   "chart <file>",
+		Short: "Draw bar charts and sparklines of OLF v2.0 file in the terminal",
+		Long: `Draw bar charts and sparklines of OLF v2.0 file in the terminal.
+
+Available charts (--type, default all):
+- networth: net worth at the end of each month
+- expenses: expenses of each month, excluding internal transfers
+- tags:     spend per tag over the period, with its monthly trend
+
+Charts use the same figures as the monthly, tag and net worth reports.
+They are sized to the terminal width, or to --width. When the output is not
+a terminal, the COLUMNS environment variable is used, then 80 columns.
+
+Examples:
+  ledger chart ledger.yaml                   # All charts
+  ledger chart ledger.yaml --type networth   # Net worth only
+  ledger chart ledger.yaml --last 12m        # Last 12 months`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			for _, t := range types {
+				if !lo.Contains(chartTypes, t) {
+					return fmt.Errorf("unsupported chart type %q, expected one of %v", t, chartTypes)
+				}
+			}
+			if width < 0 {
+				return fmt.Errorf("--width must not be negative")
+			}
+			cmd.SilenceUsage = true
+
+			_, filtered, currency, err := loadReport(cmd, path, &period)
+			if err != nil {
+				return err
+			}
+
+			if width == 0 {
+				width = terminalWidth()
+			}
+
+			w := cmd.OutOrStdout()
+			for i, t := range lo.Uniq(types) {
+				if i > 0 {
+					fmt.Fprintln(w)
+				}
+
+				switch t {
+				case chartNetWorth:
+					err = netWorthChart(w, filtered, currency, width)
+				case chartExpenses:
+					err = expensesChart(w, filtered, currency, width)
+				case chartTags:
+					err = tagsChart(w, filtered, currency, width)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&types, "type", "t", chartTypes, "Charts to draw: networth, expenses, tags")
+	cmd.Flags().IntVar(&width, "width", 0, "Chart width in columns (default: terminal width, or COLUMNS, or 80)")
+	addPeriodFlags(cmd, &period)
+
+	return cmd
+}
+
+// terminalWidth returns the width of the terminal on stdout. When stdout is not a terminal,
+// e.g. piped into a file, it falls back to the COLUMNS environment variable, then to 80.
+func terminalWidth() int {
+	if columns, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && columns > 0 {
+		return columns
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return defaultChartWidth
+}
+
+// netWorthChart draws the month-end net worth of every month of the ledger
+func netWorthChart(w io.Writer, ledger v2.Ledger, currency v2.Currency, width int) error {
+	var bars []chart.Bar
+	var values []int
+	for _, m := range ledger.NetWorth() {
+		bars = append(bars, chart.Bar{Label: m.String(), Value: m.NetWorth, Text: currency.Format(m.NetWorth)})
+		values = append(values, m.NetWorth)
+	}
+
+	fmt.Fprintf(w, "Net Worth  %s\n", chart.Sparkline(values))
+	return chart.RenderBars(w, bars, width)
+}
+
+// expensesChart draws the expenses of every month of the ledger as positive amounts
+func expensesChart(w io.Writer, ledger v2.Ledger, currency v2.Currency, width int) error {
+	var bars []chart.Bar
+	var values []int
+	for _, ym := range ledger.GetMonths() {
+		spent := -ledger.Years[ym.Year].Months[ym.Month].Expenses()
+		bars = append(bars, chart.Bar{Label: ym.String(), Value: spent, Text: currency.Format(spent)})
+		values = append(values, spent)
+	}
+
+	fmt.Fprintf(w, "Expenses  %s\n", chart.Sparkline(values))
+	return chart.RenderBars(w, bars, width)
+}
+
+// tagsChart draws the spend of every tag with expenses over the ledger,
+// followed by a sparkline of the tag's monthly spend
+func tagsChart(w io.Writer, ledger v2.Ledger, currency v2.Currency, width int) error {
+	months := ledger.GetMonths()
+	monthTotals := make([]map[string]int, 0, len(months))
+	totals := make(map[string]int)
+	for _, ym := range months {
+		tagTotals := ledger.Years[ym.Year].Months[ym.Month].TagTotals()
+		monthTotals = append(monthTotals, tagTotals)
+		for tag, amount := range tagTotals {
+			totals[tag] += amount
+		}
+	}
+
+	var bars []chart.Bar
+	for _, tag := range v2.SortTags(totals) {
+		if totals[tag] >= 0 {
+			continue
+		}
+
+		spent := -totals[tag]
+		trend := lo.Map(monthTotals, func(t map[string]int, _ int) int { return max(-t[tag], 0) })
+		bars = append(bars, chart.Bar{
+			Label: v2.TagLabel(tag),
+			Value: spent,
+			Text:  fmt.Sprintf("%s %s", currency.Format(spent), chart.Sparkline(trend)),
+		})
+	}
+
+	fmt.Fprintln(w, "Spend per Tag")
+	if len(bars) == 0 {
+		_, err := fmt.Fprintln(w, "No expenses in the selected period")
+		return err
+	}
+	return chart.RenderBars(w, bars, width)
+}
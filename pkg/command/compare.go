@@ -0,0 +1,142 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+	"strconv"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+func getCompareCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "compare <file> <base-year> <year>",
+		Short: "Compare a year against another year month by month",
+		Long: `Compare a year against another year month by month.
+
+Shows income and expenses of each month side by side with the same month of
+the base year, with absolute and percentage deltas, followed by the spending
+and income per tag.
+
+Months present in only one of the years are listed as missing and left out
+of the totals and the per-tag comparison, so a partial year is compared
+against the same months of the base year only.
+
+Examples:
+  ledger compare ledger.yaml 2024 2025          # 2025 against 2024
+  ledger compare ledger.yaml 2024 2025 -f csv   # Export as CSV
+  ledger report ledger.yaml --yoy               # Latest year against the previous one`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			baseYear, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid base year %q: must be an integer", args[1])
+			}
+			year, err := strconv.Atoi(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid year %q: must be an integer", args[2])
+			}
+			reportFormat, err := parseReportFormat(format)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			ledger, _, currency, err := loadReport(cmd, path, nil)
+			if err != nil {
+				return err
+			}
+
+			tables, err := compareTables(ledger, baseYear, year)
+			if err != nil {
+				return err
+			}
+
+			return renderReports(cmd, tables, reportFormat, currency)
+		},
+	}
+
+	addReportFormatFlag(cmd, &format)
+
+	return cmd
+}
+
+// compareTables computes the month-by-month and per-tag comparison of a year against a base year
+func compareTables(ledger v2.Ledger, baseYear, year int) ([]report.Table, error) {
+	for _, y := range []int{baseYear, year} {
+		if _, ok := ledger.Years[y]; !ok {
+			return nil, fmt.Errorf("year %d not found in ledger", y)
+		}
+	}
+
+	cmp := ledger.CompareYears(baseYear, year)
+	base, current := strconv.Itoa(baseYear), strconv.Itoa(year)
+
+	months := report.Table{Title: fmt.Sprintf("Income and expenses: %s vs %s", current, base)}
+	months.AddNumberColumn("Month")
+	addDeltaColumns(&months, "Income "+base, "Income "+current)
+	addDeltaColumns(&months, "Expenses "+base, "Expenses "+current)
+
+	for _, m := range cmp.Months {
+		cells := []any{m.Month}
+		cells = append(cells, monthDeltaCells(m, m.Income)...)
+		cells = append(cells, monthDeltaCells(m, m.Expenses)...)
+		months.AddRow(cells...)
+	}
+
+	footer := []any{"Total"}
+	footer = append(footer, deltaCells(cmp.Income)...)
+	footer = append(footer, deltaCells(cmp.Expenses)...)
+	months.SetFooter(footer...)
+
+	tags := report.Table{Title: fmt.Sprintf("Tags: %s vs %s (matching months)", current, base)}
+	tags.AddColumn("Tag")
+	addDeltaColumns(&tags, base, current)
+
+	values := lo.MapValues(cmp.Tags, func(d v2.Delta, _ string) int { return d.Value })
+	for _, tag := range v2.SortTags(values) {
+		tags.AddRow(append([]any{v2.TagLabel(tag)}, deltaCells(cmp.Tags[tag])...)...)
+	}
+
+	return []report.Table{months, tags}, nil
+}
+
+// addDeltaColumns appends the base value, value, change and percent change columns
+func addDeltaColumns(t *report.Table, base, value string) {
+	t.AddNumberColumn(base)
+	t.AddNumberColumn(value)
+	t.AddNumberColumn("Δ")
+	t.AddNumberColumn("Δ%")
+}
+
+// deltaCells returns the base value, value, change and percent change cells
+func deltaCells(d v2.Delta) []any {
+	return []any{
+		report.Amount(d.Base),
+		report.Amount(d.Value),
+		report.Amount(d.Change()),
+		report.OptionalPercent(d.Percent()),
+	}
+}
+
+// monthDeltaCells returns the delta cells of a month, marking the side where the month is missing
+func monthDeltaCells(m v2.MonthComparison, d v2.Delta) []any {
+	if m.Matching() {
+		return deltaCells(d)
+	}
+
+	var base, value any = report.Amount(d.Base), report.Amount(d.Value)
+	if !m.InBase {
+		base = "missing"
+	}
+	if !m.InYear {
+		value = "missing"
+	}
+	return []any{base, value, nil, nil}
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+
+	"github.com/spf13/cobra"
+)
+
+// addCurrencyFlags registers the flags overriding how amounts are displayed in reports
+func addCurrencyFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Int("scale", 0,
+		"Stored units per currency unit, e.g. 1 for whole units or 100 for cents (default from the ledger, or 1000)")
+	cmd.PersistentFlags().String("currency", "", "Currency symbol prepended to amounts, e.g. $ (default from the ledger)")
+	cmd.PersistentFlags().Int("decimals", 0, "Decimal places displayed (default from the ledger, or 2)")
+	cmd.PersistentFlags().String("thousands-separator", "", "Separator between groups of thousands, e.g. , (default from the ledger)")
+	cmd.PersistentFlags().String("decimal-separator", "", "Separator of the fractional part (default from the ledger, or .)")
+}
+
+// getCurrency returns the ledger currency settings overridden by the flags given on the command line
+func getCurrency(cmd *cobra.Command, ledger v2.Currency) (v2.Currency, error) {
+	currency := ledger
+	flags := cmd.Flags()
+
+	if flags.Changed("scale") {
+		currency.Scale, _ = flags.GetInt("scale")
+		if currency.Scale == 0 {
+			return v2.Currency{}, fmt.Errorf("invalid currency: scale must be positive")
+		}
+	}
+	if flags.Changed("currency") {
+		currency.Symbol, _ = flags.GetString("currency")
+	}
+	if flags.Changed("decimals") {
+		decimals, _ := flags.GetInt("decimals")
+		currency.Decimals = &decimals
+	}
+	if flags.Changed("thousands-separator") {
+		currency.ThousandsSeparator, _ = flags.GetString("thousands-separator")
+	}
+	if flags.Changed("decimal-separator") {
+		currency.DecimalSeparator, _ = flags.GetString("decimal-separator")
+	}
+
+	if err := currency.Validate(); err != nil {
+		return v2.Currency{}, fmt.Errorf("invalid currency: %w", err)
+	}
+
+	return currency, nil
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	v2 "ledger/pkg/ledger/v2"
+)
+
+// Exit codes of the ledger binary
+const (
+	ExitOK         = 0 // success
+	ExitError      = 1 // any other error, e.g. invalid arguments
+	ExitViolations = 2 // the ledger violates OLF invariants
+	ExitParseError = 3 // the ledger file could not be parsed
+	ExitIOError    = 4 // the ledger file could not be read or written
+)
+
+// violationsError reports that a ledger does not conform to the specification
+type violationsError struct {
+	count int
+}
+
+func (e violationsError) Error() string {
+	return fmt.Sprintf("validation failed: %d violation(s) found", e.count)
+}
+
+// ExitCode maps an error returned by the root command to the process exit code
+func ExitCode(err error) int {
+	var violations violationsError
+	var violation *v2.Violation
+	var pathErr *fs.PathError
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &violations), errors.As(err, &violation):
+		return ExitViolations
+	case errors.Is(err, v2.ErrParse), errors.Is(err, v2.ErrUnsupportedFormat):
+		return ExitParseError
+	case errors.Is(err, v2.ErrRead), errors.Is(err, v2.ErrWrite), errors.As(err, &pathErr):
+		return ExitIOError
+	default:
+		return ExitError
+	}
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"fmt"
+	"ledger/pkg/export"
+
+	"github.com/spf13/cobra"
+)
+
+func getExportCmd() *cobra.Command {
+	var exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export OLF v2.0 file for viewing outside the CLI",
+		Long: `Export OLF v2.0 file for viewing outside the CLI.
+
+Exports are read-only views generated from the ledger; they are never read back.`,
+	}
+
+	exportCmd.AddCommand(getExportHTMLCmd())
+
+	return exportCmd
+}
+
+func getExportHTMLCmd() *cobra.Command {
+	var period periodFlags
+
+	cmd := &cobra.Command{
+		Use:   "html <file> <outdir>",
+		Short: "Generate a static HTML dashboard from OLF v2.0 file",
+		Long: `Generate a static HTML dashboard from OLF v2.0 file.
+
+The output directory receives a self-contained site that can be opened
+in any browser or hosted as is: no scripts, no external stylesheets,
+fonts or CDNs, and charts drawn as inline SVG. It contains:
+- index.html: yearly and monthly totals with a net worth chart
+- YYYY-MM.html: a page per month with its accounts, entries and tags
+- tags.html: totals per tag and year
+
+Existing files with the same names in the output directory are overwritten.
+
+Examples:
+  ledger export html ledger.yaml site/              # Whole ledger
+  ledger export html ledger.yaml site/ --year 2025  # A single year`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, outDir := args[0], args[1]
+			cmd.SilenceUsage = true
+
+			_, filtered, currency, err := loadReport(cmd, path, &period)
+			if err != nil {
+				return err
+			}
+
+			err = export.HTML(filtered, currency, outDir)
+			if err != nil {
+				return fmt.Errorf("failed to export: %w", err)
+			}
+
+			cmd.Printf("✓ Exported %d months to %s\n", len(filtered.GetMonths()), outDir)
+			return nil
+		},
+	}
+
+	addPeriodFlags(cmd, &period)
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+
+	"github.com/spf13/cobra"
+)
+
+func getV2FixCmd() *cobra.Command {
+	var dryRun bool
+	var noBackup bool
+
+	cmd := &cobra.Command{
+		Use:   "fix <file>",
+		Short: "Recompute derived balances of an OLF v2.0 file",
+		Long: `Recompute derived balances of an OLF v2.0 file.
+
+Treats entries and the opening balances of the accounts in the very first
+month as the source of truth and rewrites every derived balance so that the
+ledger validates:
+- Account closing balances (A-1)
+- Account opening balances from the previous month, or 0 for new accounts (A-2, A-3)
+- Month opening and closing totals (M-1, M-2, M-3)
+- Year opening and closing totals (Y-1, Y-2, Y-3)
+
+Violations that cannot be fixed mechanically, e.g. invalid entries or omitted
+accounts with a non-zero balance, are reported after fixing.
+
+Use --dry-run to print the changes as a diff without writing the file.
+With "-" as the file, the ledger is read from stdin and the fixed ledger is
+written to stdout, while the diff is printed to stderr.
+The file is replaced atomically and the previous version is kept as
+<file>.bak.1 (up to 3 rotating backups) unless --no-backup is given.
+
+Examples:
+  ledger fix ledger.yaml               # Fix balances in place
+  ledger fix ledger.yaml --dry-run     # Show what would change
+  ledger fix - < in.yaml > out.yaml    # Fix a ledger in a pipeline`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			cmd.SilenceUsage = true
+
+			ledger, source, err := readLedger(cmd, path)
+			if err != nil {
+				return fmt.Errorf("failed to read ledger file: %w", err)
+			}
+
+			fixed, changes := ledger.Fix()
+			if len(changes) == 0 {
+				cmd.Println("✓ All derived balances are correct")
+			} else {
+				printChanges(cmd, changes, source)
+			}
+
+			// A ledger read from stdin is always passed through to stdout, changed or not
+			if (len(changes) > 0 || path == v2.StdioPath) && !dryRun {
+				opts := writeOptions(cmd, noBackup)
+				// Without --output-format, stdout keeps the format read from stdin
+				if opts.Format == "" && path == v2.StdioPath {
+					opts.Format = source.Format()
+				}
+				err = v2.WriteLedgerWithOptions(fixed, path, opts)
+				if err != nil {
+					return fmt.Errorf("failed to write ledger file: %w", err)
+				}
+				if len(changes) > 0 {
+					cmd.Printf("✓ Fixed %d balance(s) in %s\n", len(changes), path)
+				}
+			}
+
+			violations := fixed.ValidateAll()
+			if len(violations) > 0 {
+				cmd.Println("Remaining violations must be fixed manually:")
+				printViolations(cmd, violations, source)
+				return violationsError{count: len(violations)}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Print changes without writing the file")
+	addBackupFlag(cmd, &noBackup)
+
+	return cmd
+}
+
+// printChanges prints fixed balances as a diff, one hunk per year, month or account
+func printChanges(cmd *cobra.Command, changes []v2.Change, source v2.SourceMap) {
+	for _, change := range changes {
+		header := change.Path.String()
+		if loc, ok := source.LocateField(change.Path, change.Field); ok {
+			header = fmt.Sprintf("%s: %s", loc, header)
+		}
+
+		cmd.Printf("@@ %s\n", header)
+		cmd.Printf("-  %s: %d\n", change.Field, change.Old)
+		cmd.Printf("+  %s: %d\n", change.Field, change.New)
+	}
+}
@@ -0,0 +1,213 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func getForecastCmd() *cobra.Command {
+	var format string
+	var months, window int
+	var byTag bool
+	var scheduled []string
+
+	cmd := &cobra.Command{
+		Use:   "forecast <file>",
+		Short: "Project future month-end balances of OLF v2.0 file",
+		Long: `Project future month-end balances of OLF v2.0 file.
+
+The forecast assumes every coming month brings the average income and
+expenses (excluding internal transfers) of the latest --window months of the
+ledger, starting from the closing balance of its latest month. Known future
+items, e.g. an insurance premium or a bonus, can be added with --scheduled
+as MONTH:AMOUNT[:NOTE], where the amount is in currency units and negative
+for expenses. The averaged months are listed before the projection.
+
+With --by-tag the average per tag is shown as well, to see which tags
+drive the projection.
+
+Examples:
+  ledger forecast ledger.yaml                             # Next 12 months
+  ledger forecast ledger.yaml --months 24 --window 6      # 2 years from the last 6 months
+  ledger forecast ledger.yaml --scheduled "2026-03:-1200:Car insurance"
+  ledger forecast ledger.yaml --by-tag -f csv             # Export as CSV`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			reportFormat, err := parseReportFormat(format)
+			if err != nil {
+				return err
+			}
+			if months <= 0 {
+				return fmt.Errorf("--months must be positive")
+			}
+			if window < 0 {
+				return fmt.Errorf("--window must not be negative")
+			}
+			cmd.SilenceUsage = true
+
+			ledger, _, currency, err := loadReport(cmd, path, nil)
+			if err != nil {
+				return err
+			}
+
+			history := ledger.GetMonths()
+			if len(history) == 0 {
+				return fmt.Errorf("no months in the ledger to forecast from")
+			}
+			latest := history[len(history)-1]
+
+			opts := v2.ForecastOptions{Months: months, Window: window}
+			for _, s := range scheduled {
+				item, err := parseScheduledItem(s, currency)
+				if err != nil {
+					return err
+				}
+				if !item.YearMonth.Before(latest.AddMonths(months+1)) || !latest.Before(item.YearMonth) {
+					return fmt.Errorf("scheduled item %q is outside the forecast from %s to %s",
+						s, latest.AddMonths(1), latest.AddMonths(months))
+				}
+				opts.Scheduled = append(opts.Scheduled, item)
+			}
+
+			forecast := ledger.Forecast(opts)
+			tables := []report.Table{forecastTable(ledger, forecast)}
+			if len(opts.Scheduled) > 0 {
+				tables = append(tables, scheduledTable(forecast))
+			}
+			if byTag {
+				tables = append(tables, forecastTagTable(forecast, months))
+			}
+
+			return renderReports(cmd, tables, reportFormat, currency)
+		},
+	}
+
+	cmd.Flags().IntVar(&months, "months", 12, "Number of months to project")
+	cmd.Flags().IntVar(&window, "window", 12, "Number of latest months to average, 0 for all months")
+	cmd.Flags().BoolVar(&byTag, "by-tag", false, "Show the average per tag")
+	cmd.Flags().StringArrayVar(&scheduled, "scheduled", nil, "Known future item as MONTH:AMOUNT[:NOTE], e.g. 2026-03:-1200:Insurance (repeatable)")
+	addReportFormatFlag(cmd, &format)
+
+	return cmd
+}
+
+// parseScheduledItem parses a scheduled item in the form YYYY-MM:AMOUNT[:NOTE]
+func parseScheduledItem(s string, currency v2.Currency) (v2.ScheduledItem, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) < 2 {
+		return v2.ScheduledItem{}, fmt.Errorf("invalid scheduled item %q: expected MONTH:AMOUNT[:NOTE]", s)
+	}
+
+	ym, err := v2.ParseYearMonth(parts[0])
+	if err != nil {
+		return v2.ScheduledItem{}, fmt.Errorf("invalid scheduled item %q: %w", s, err)
+	}
+	amount, err := currency.ParseAmount(parts[1])
+	if err != nil {
+		return v2.ScheduledItem{}, fmt.Errorf("invalid scheduled item %q: %w", s, err)
+	}
+
+	item := v2.ScheduledItem{YearMonth: ym, Amount: amount}
+	if len(parts) == 3 {
+		item.Note = parts[2]
+	}
+	return item, nil
+}
+
+// forecastTable lists the averaged months of the ledger followed by the projected months
+func forecastTable(ledger v2.Ledger, forecast v2.Forecast) report.Table {
+	t := report.Table{Title: fmt.Sprintf("Forecast from the average of %d month(s)", len(forecast.History))}
+	t.AddNumberColumn("Year")
+	t.AddNumberColumn("Month")
+	t.AddColumn("Kind")
+	t.AddNumberColumn("Income")
+	t.AddNumberColumn("Expenses")
+	t.AddNumberColumn("Scheduled")
+	t.AddNumberColumn("Net")
+	t.AddNumberColumn("Closing Balance")
+
+	for _, ym := range forecast.History {
+		month := ledger.Years[ym.Year].Months[ym.Month]
+		t.AddRow(
+			ym.Year,
+			ym.Month,
+			"actual",
+			report.Amount(month.Income()),
+			report.Amount(month.Expenses()),
+			nil,
+			report.Amount(month.ClosingBalance-month.OpeningBalance),
+			report.Amount(month.ClosingBalance),
+		)
+	}
+
+	for i, m := range forecast.Months {
+		t.Rows = append(t.Rows, report.Row{
+			Cells: []any{
+				m.Year,
+				m.Month,
+				"forecast",
+				report.Amount(m.Income),
+				report.Amount(m.Expenses),
+				report.Amount(m.ScheduledTotal()),
+				report.Amount(m.Net()),
+				report.Amount(m.ClosingBalance),
+			},
+			Separator: i == 0,
+		})
+	}
+
+	t.SetFooter(
+		"Average",
+		nil,
+		nil,
+		report.Amount(forecast.Income),
+		report.Amount(forecast.Expenses),
+		nil,
+		report.Amount(forecast.Income+forecast.Expenses),
+		nil,
+	)
+
+	return t
+}
+
+// scheduledTable lists the scheduled items of the forecast
+func scheduledTable(forecast v2.Forecast) report.Table {
+	t := report.Table{Title: "Scheduled items"}
+	t.AddColumn("Month")
+	t.AddColumn("Note")
+	t.AddNumberColumn("Amount")
+
+	total := 0
+	for _, m := range forecast.Months {
+		for _, item := range m.Scheduled {
+			t.AddRow(item.String(), item.Note, report.Amount(item.Amount))
+			total += item.Amount
+		}
+	}
+	t.SetFooter("Total", nil, report.Amount(total))
+
+	return t
+}
+
+// forecastTagTable lists the average per tag and its projection over the forecast months
+func forecastTagTable(forecast v2.Forecast, months int) report.Table {
+	t := report.Table{Title: "Average per tag"}
+	t.AddColumn("Tag")
+	t.AddNumberColumn("Monthly Average")
+	t.AddNumberColumn(fmt.Sprintf("Next %d Month(s)", months))
+
+	total := 0
+	for _, tag := range v2.SortTags(forecast.Tags) {
+		t.AddRow(v2.TagLabel(tag), report.Amount(forecast.Tags[tag]), report.Amount(forecast.Tags[tag]*months))
+		total += forecast.Tags[tag]
+	}
+	t.SetFooter("Total", report.Amount(total), report.Amount(total*months))
+
+	return t
+}
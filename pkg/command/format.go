@@ -0,0 +1,32 @@
+package command
+
+import (
+	v2 "ledger/pkg/ledger/v2"
+
+	"github.com/spf13/cobra"
+)
+
+// addFormatFlags registers the --input-format and --output-format flags shared by every command
+func addFormatFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("input-format", "",
+		"Format of ledger files read: yaml, json or toml (default from the file extension; JSON or YAML for stdin)")
+	cmd.PersistentFlags().String("output-format", "",
+		"Format of ledger files written: yaml, json or toml (default from the file extension; the format read from stdin for stdout)")
+}
+
+// inputFormat returns the --input-format override, empty to detect the format from the path
+func inputFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("input-format")
+	return format
+}
+
+// outputFormat returns the --output-format override, empty to detect the format from the path
+func outputFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("output-format")
+	return format
+}
+
+// readLedger reads an OLF v2.0 ledger file, or stdin for "-", according to the --input-format flag
+func readLedger(cmd *cobra.Command, path string) (v2.Ledger, v2.SourceMap, error) {
+	return v2.ReadLedgerWithOptions(path, v2.ReadOptions{Format: v2.Format(inputFormat(cmd))})
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+
+	"github.com/spf13/cobra"
+)
+
+func getNetWorthCmd() *cobra.Command {
+	var format string
+	var period periodFlags
+
+	cmd := &cobra.Command{
+		Use:   "networth <file>",
+		Short: "Show net worth and savings rate over time from OLF v2.0 file",
+		Long: `Show net worth and savings rate over time from OLF v2.0 file.
+
+Net worth is the closing balance across all accounts at the end of each month.
+For every month the report shows:
+- Net worth at month end
+- Change over the month and since the same month of the previous year
+- Income and expenses (excluding internal transfers)
+- Savings rate: (income + expenses) / income
+- Savings rate over the trailing 3 and 12 months (3m Rate, 12m Rate): the
+  rate of their summed income and expenses, not an average of monthly rates
+
+The trailing figures and year-over-year changes use the whole ledger, even
+when the report is limited with --from/--to, --year, --last or --ytd. The
+totals row shows the change and savings rate over the selected period.
+
+Examples:
+  ledger networth ledger.yaml              # Net worth for every month
+  ledger networth ledger.yaml --last 12m   # Last 12 months
+  ledger networth ledger.yaml -f csv       # Export as CSV`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			reportFormat, err := parseReportFormat(format)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			ledger, filtered, currency, err := loadReport(cmd, path, &period)
+			if err != nil {
+				return err
+			}
+
+			return renderReport(cmd, netWorthTable(ledger.NetWorth(), filtered), reportFormat, currency)
+		},
+	}
+
+	addPeriodFlags(cmd, &period)
+	addReportFormatFlag(cmd, &format)
+
+	return cmd
+}
+
+// netWorthTable computes the net worth report for the months of the period
+func netWorthTable(months []v2.NetWorthMonth, period v2.Ledger) report.Table {
+	var t report.Table
+	t.AddNumberColumn("Year")
+	t.AddNumberColumn("Month")
+	t.AddNumberColumn("Net Worth")
+	t.AddNumberColumn("Change")
+	t.AddNumberColumn("YoY Change")
+	t.AddNumberColumn("Income")
+	t.AddNumberColumn("Expenses")
+	t.AddNumberColumn("Savings Rate")
+	t.AddNumberColumn("3m Rate")
+	t.AddNumberColumn("12m Rate")
+
+	prevYear := 0
+	for _, m := range months {
+		if _, ok := period.Years[m.Year].Months[m.Month]; !ok {
+			continue
+		}
+
+		t.Rows = append(t.Rows, report.Row{
+			Cells: []any{
+				m.Year,
+				m.Month,
+				report.Amount(m.NetWorth),
+				report.Amount(m.Change),
+				report.OptionalAmount(m.YearChange),
+				report.Amount(m.Income),
+				report.Amount(m.Expenses),
+				report.OptionalPercent(m.SavingsRate),
+				report.OptionalPercent(m.SavingsRate3),
+				report.OptionalPercent(m.SavingsRate12),
+			},
+			Separator: m.Year != prevYear,
+		})
+		prevYear = m.Year
+	}
+
+	t.SetFooter(
+		"Total",
+		nil,
+		report.Amount(period.ClosingBalance()),
+		report.Amount(period.ClosingBalance()-period.OpeningBalance()),
+		nil,
+		report.Amount(period.Income()),
+		report.Amount(period.Expenses()),
+		report.OptionalPercent(v2.SavingsRate(period.Income(), period.Expenses())),
+		nil,
+		nil,
+	)
+
+	return t
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// periodFlags selects the window of months a report covers
+type periodFlags struct {
+	from        string
+	to          string
+	year        int
+	last        string
+	ytd         bool
+	yearOrMonth string
+}
+
+// addPeriodFlags registers the flags selecting the months covered by a report
+func addPeriodFlags(cmd *cobra.Command, p *periodFlags) {
+	cmd.Flags().StringVar(&p.from, "from", "", "First month to report, in YYYY-MM format")
+	cmd.Flags().StringVar(&p.to, "to", "", "Last month to report, in YYYY-MM format")
+	cmd.Flags().IntVar(&p.year, "year", 0, "Report a single year only")
+	cmd.Flags().StringVar(&p.last, "last", "", "Report the last N months (e.g. 12m) or years (e.g. 2y) up to the latest month of the ledger")
+	cmd.Flags().BoolVar(&p.ytd, "ytd", false, "Report the year to date, up to the latest month of the ledger")
+	cmd.Flags().StringVar(&p.yearOrMonth, "period", "", "Report a single year (YYYY) or month (YYYY-MM)")
+
+	cmd.MarkFlagsMutuallyExclusive("year", "last", "ytd", "period", "from")
+	cmd.MarkFlagsMutuallyExclusive("year", "last", "ytd", "period", "to")
+}
+
+// filter returns the ledger restricted to the selected period.
+// Relative periods (--last, --ytd) end at the latest month of the ledger.
+func (p periodFlags) filter(ledger v2.Ledger) (v2.Ledger, error) {
+	period, err := p.period(ledger)
+	if err != nil {
+		return v2.Ledger{}, err
+	}
+
+	filtered := ledger.Filter(period)
+	if len(filtered.GetMonths()) == 0 {
+		return v2.Ledger{}, fmt.Errorf("no months of the ledger in the selected period")
+	}
+
+	return filtered, nil
+}
+
+func (p periodFlags) period(ledger v2.Ledger) (v2.Period, error) {
+	months := ledger.GetMonths()
+	var latest v2.YearMonth
+	if len(months) > 0 {
+		latest = months[len(months)-1]
+	}
+
+	switch {
+	case p.year != 0:
+		return v2.Period{From: v2.YearMonth{Year: p.year, Month: 1}, To: v2.YearMonth{Year: p.year, Month: 12}}, nil
+	case p.ytd:
+		return v2.Period{From: v2.YearMonth{Year: latest.Year, Month: 1}, To: latest}, nil
+	case p.yearOrMonth != "":
+		return parsePeriod(p.yearOrMonth)
+	case p.last != "":
+		n, err := parseMonthCount(p.last)
+		if err != nil {
+			return v2.Period{}, err
+		}
+		return v2.Period{From: latest.AddMonths(1 - n), To: latest}, nil
+	}
+
+	var period v2.Period
+	var err error
+	if p.from != "" {
+		if period.From, err = v2.ParseYearMonth(p.from); err != nil {
+			return v2.Period{}, fmt.Errorf("invalid --from: %w", err)
+		}
+	}
+	if p.to != "" {
+		if period.To, err = v2.ParseYearMonth(p.to); err != nil {
+			return v2.Period{}, fmt.Errorf("invalid --to: %w", err)
+		}
+	}
+	if !period.From.IsZero() && !period.To.IsZero() && period.To.Before(period.From) {
+		return v2.Period{}, fmt.Errorf("invalid period: %s is before %s", period.To, period.From)
+	}
+
+	return period, nil
+}
+
+// parsePeriod parses a single year (YYYY) or month (YYYY-MM) into a period
+func parsePeriod(s string) (v2.Period, error) {
+	if ym, err := v2.ParseYearMonth(s); err == nil {
+		return v2.Period{From: ym, To: ym}, nil
+	}
+
+	year, err := strconv.Atoi(s)
+	if err != nil || year <= 0 {
+		return v2.Period{}, fmt.Errorf("invalid --period %q: must be a year (YYYY) or a month (YYYY-MM)", s)
+	}
+	return v2.Period{From: v2.YearMonth{Year: year, Month: 1}, To: v2.YearMonth{Year: year, Month: 12}}, nil
+}
+
+// parseMonthCount parses a duration such as "12m", "2y" or "6" (months) into a number of months
+func parseMonthCount(s string) (int, error) {
+	unit := 1
+	number := s
+	switch {
+	case strings.HasSuffix(s, "m"):
+		number = strings.TrimSuffix(s, "m")
+	case strings.HasSuffix(s, "y"):
+		number = strings.TrimSuffix(s, "y")
+		unit = 12
+	}
+
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid --last %q: must be a positive number of months (e.g. 12m) or years (e.g. 2y)", s)
+	}
+	return n * unit, nil
+}
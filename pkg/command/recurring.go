@@ -0,0 +1,154 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+func getRecurringCmd() *cobra.Command {
+	var format string
+	var minOccurrences int
+	var tolerance float64
+	var status string
+	var period periodFlags
+
+	cmd := &cobra.Command{
+		Use:   "recurring <file>",
+		Short: "Detect recurring entries of OLF v2.0 file",
+		Long: `Detect recurring entries of OLF v2.0 file, such as subscriptions and bills.
+
+Entries are grouped into series by their normalized note (lowercase, without
+digits, punctuation and month names, so "Salary (March)" matches "Salary
+(April)"), tag and account. A series recurs when it appears in at least
+--min months; entries of a series within one month are summed.
+
+For every series the report shows:
+- Cadence: the typical number of months between occurrences
+- Typical amount (median) and latest amount
+- Last seen: the latest date, or month without dates
+- Changes: how often the amount changed by more than --tolerance percent
+- Missed: expected months without an occurrence
+- Status: active, missed (the latest expected occurrence is missing) or
+  stopped (two or more are missing up to the latest month of the ledger)
+Every amount change is listed in a second table.
+
+Examples:
+  ledger recurring ledger.yaml                   # All recurring series
+  ledger recurring ledger.yaml --status stopped  # Series that stopped
+  ledger recurring ledger.yaml --tolerance 5     # Ignore changes up to 5%
+  ledger recurring ledger.yaml --last 2y         # Last 2 years only`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			reportFormat, err := parseReportFormat(format)
+			if err != nil {
+				return err
+			}
+			if minOccurrences < 2 {
+				return fmt.Errorf("--min must be at least 2")
+			}
+			if tolerance < 0 {
+				return fmt.Errorf("--tolerance must not be negative")
+			}
+			statuses := []v2.SeriesStatus{v2.SeriesActive, v2.SeriesMissed, v2.SeriesStopped}
+			if status != "" && !lo.Contains(statuses, v2.SeriesStatus(status)) {
+				return fmt.Errorf("unsupported status %q, expected one of %v", status, statuses)
+			}
+			cmd.SilenceUsage = true
+
+			_, filtered, currency, err := loadReport(cmd, path, &period)
+			if err != nil {
+				return err
+			}
+
+			series := filtered.Recurring(v2.RecurringOptions{MinOccurrences: minOccurrences, Tolerance: tolerance})
+			if status != "" {
+				series = lo.Filter(series, func(s v2.RecurringSeries, _ int) bool { return s.Status == v2.SeriesStatus(status) })
+			}
+
+			return renderReports(cmd, recurringTables(series), reportFormat, currency)
+		},
+	}
+
+	cmd.Flags().IntVar(&minOccurrences, "min", v2.DefaultMinOccurrences, "Minimum number of months a series must appear in")
+	cmd.Flags().Float64Var(&tolerance, "tolerance", 0, "Amount changes up to this percentage are ignored")
+	cmd.Flags().StringVar(&status, "status", "", "Only show series with this status: active, missed or stopped")
+	addPeriodFlags(cmd, &period)
+	addReportFormatFlag(cmd, &format)
+
+	return cmd
+}
+
+// recurringTables computes the table of recurring series and the table of their amount changes
+func recurringTables(series []v2.RecurringSeries) []report.Table {
+	summary := report.Table{Title: "Recurring entries"}
+	summary.AddColumn("Note")
+	summary.AddColumn("Tag")
+	summary.AddColumn("Account")
+	summary.AddColumn("Cadence")
+	summary.AddNumberColumn("Count")
+	summary.AddNumberColumn("Typical")
+	summary.AddNumberColumn("Latest")
+	summary.AddColumn("Last Seen")
+	summary.AddNumberColumn("Changes")
+	summary.AddColumn("Missed")
+	summary.AddColumn("Status")
+
+	changes := report.Table{Title: "Amount changes"}
+	changes.AddColumn("Note")
+	changes.AddColumn("Account")
+	changes.AddColumn("Month")
+	addDeltaColumns(&changes, "Before", "After")
+
+	for _, s := range series {
+		last := s.Last()
+		lastSeen := last.Date
+		if lastSeen == "" {
+			lastSeen = last.String()
+		}
+
+		summary.AddRow(
+			last.Note,
+			s.Tag,
+			s.Account,
+			cadenceName(s.Cadence),
+			len(s.Occurrences),
+			report.Amount(s.Typical),
+			report.Amount(last.Amount),
+			lastSeen,
+			len(s.Changes),
+			strings.Join(lo.Map(s.Missed, func(ym v2.YearMonth, _ int) string { return ym.String() }), ", "),
+			string(s.Status),
+		)
+
+		for _, c := range s.Changes {
+			changes.AddRow(append([]any{last.Note, s.Account, c.String()}, deltaCells(c.Delta)...)...)
+		}
+	}
+
+	return []report.Table{summary, changes}
+}
+
+// cadenceName describes the number of months between occurrences, e.g. "monthly" or "every 4 months"
+func cadenceName(months int) string {
+	switch months {
+	case 1:
+		return "monthly"
+	case 2:
+		return "bimonthly"
+	case 3:
+		return "quarterly"
+	case 6:
+		return "semiannual"
+	case 12:
+		return "yearly"
+	default:
+		return fmt.Sprintf("every %d months", months)
+	}
+}
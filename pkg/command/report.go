@@ -0,0 +1,78 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+
+	"github.com/spf13/cobra"
+)
+
+// addReportFormatFlag registers the --format flag shared by every command printing a report
+func addReportFormatFlag(cmd *cobra.Command, format *string) {
+	cmd.Flags().StringVarP(format, "format", "f", string(report.FormatTable),
+		"Report format: table, csv, json, markdown or html")
+}
+
+// parseReportFormat checks the --format flag before any work is done
+func parseReportFormat(format string) (report.Format, error) {
+	f, err := report.ParseFormat(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid --format: %w", err)
+	}
+	return f, nil
+}
+
+// loadReport reads and validates the ledger of a report command and resolves its currency.
+// It returns the whole ledger, for figures needing the months before the selected period,
+// and the ledger restricted to the period flags; a nil period selects the whole ledger.
+func loadReport(cmd *cobra.Command, path string, period *periodFlags) (ledger, filtered v2.Ledger, currency v2.Currency, err error) {
+	ledger, _, err = readLedger(cmd, path)
+	if err != nil {
+		return v2.Ledger{}, v2.Ledger{}, v2.Currency{}, fmt.Errorf("failed to read ledger file: %w", err)
+	}
+
+	if err := ledger.Validate(); err != nil {
+		return v2.Ledger{}, v2.Ledger{}, v2.Currency{}, fmt.Errorf("validation failed: %w", err)
+	}
+
+	currency, err = getCurrency(cmd, ledger.GetCurrency())
+	if err != nil {
+		return v2.Ledger{}, v2.Ledger{}, v2.Currency{}, err
+	}
+
+	filtered = ledger
+	if period != nil {
+		if filtered, err = period.filter(ledger); err != nil {
+			return v2.Ledger{}, v2.Ledger{}, v2.Currency{}, err
+		}
+	}
+
+	return ledger, filtered, currency, nil
+}
+
+// renderReport writes a report to the command output in the requested format
+func renderReport(cmd *cobra.Command, t report.Table, format report.Format, currency v2.Currency) error {
+	if err := report.Render(cmd.OutOrStdout(), t, format, currency); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
+
+// printLastColumn prints the last column of a report as bare numbers, one per line,
+// for the condensed --short reports consumed by scripts
+func printLastColumn(cmd *cobra.Command, t report.Table, currency v2.Currency) {
+	for _, row := range t.Rows {
+		if amount, ok := row.Cells[len(row.Cells)-1].(report.Amount); ok {
+			fmt.Fprintln(cmd.OutOrStdout(), currency.FormatNumber(int(amount)))
+		}
+	}
+}
+
+// renderReports writes several reports to the command output in the requested format
+func renderReports(cmd *cobra.Command, tables []report.Table, format report.Format, currency v2.Currency) error {
+	if err := report.RenderTables(cmd.OutOrStdout(), tables, format, currency); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
@@ -9,20 +9,46 @@ func GetRootCmd() *cobra.Command {
 		Long: `Command-line tool for managing financial ledgers using Open Ledger Format (OLF).
 
 Supports both OLF v1.0 (legacy) and v2.0 formats:
-- Root commands work with OLF v2.0 (YAML/JSON)
+- Root commands work with OLF v2.0 (YAML/JSON/TOML)
 - v1 subcommands work with OLF v1.0 (YAML only)
 
 Examples:
   ledger validate ledger.yaml          # Validate OLF v2.0 file
   ledger report ledger.yaml            # Generate OLF v2.0 report
+  ledger fix ledger.yaml               # Recompute derived balances
+  ledger networth ledger.yaml          # Net worth and savings rate over time
+  ledger compare ledger.yaml 2024 2025 # Compare 2025 against 2024
+  ledger budget ledger.yaml            # Spending against budgets
+  ledger forecast ledger.yaml          # Projected balances
+  ledger recurring ledger.yaml         # Subscriptions and bills
+  ledger anomalies ledger.yaml         # Unusual months and entries
+  ledger top ledger.yaml --period 2025 # Largest expenses and incomes
+  ledger transfers ledger.yaml         # Money moved between accounts
+  ledger chart ledger.yaml             # Charts in the terminal
+  ledger export html ledger.yaml site/ # Static HTML dashboard
+  sops -d ledger.enc.yaml | ledger report -   # Read ledger from stdin
   ledger v1 validate data.yaml         # Validate OLF v1.0 file
   ledger v1 report data.yaml           # Generate OLF v1.0 report`,
 		Version: version,
 	}
 
+	addFormatFlags(&rootCmd)
+	addCurrencyFlags(&rootCmd)
+
 	// Add v2 commands as root commands
 	rootCmd.AddCommand(getV2ValidateCmd())
 	rootCmd.AddCommand(getV2ReportCmd())
+	rootCmd.AddCommand(getV2FixCmd())
+	rootCmd.AddCommand(getNetWorthCmd())
+	rootCmd.AddCommand(getCompareCmd())
+	rootCmd.AddCommand(getBudgetCmd())
+	rootCmd.AddCommand(getForecastCmd())
+	rootCmd.AddCommand(getRecurringCmd())
+	rootCmd.AddCommand(getAnomaliesCmd())
+	rootCmd.AddCommand(getTopCmd())
+	rootCmd.AddCommand(getTransfersCmd())
+	rootCmd.AddCommand(getChartCmd())
+	rootCmd.AddCommand(getExportCmd())
 
 	// Add version command
 	rootCmd.AddCommand(getVersionCmd())
@@ -0,0 +1,169 @@
+package command
+
+import (
+	"encoding/json"
+	"io"
+	v2 "ledger/pkg/ledger/v2"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// SARIF 2.1.0 log, limited to the parts needed to report validation violations
+// as code-scanning annotations
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// sarifParseRule is the rule reported when the ledger file cannot be parsed
+const sarifParseRule = "PARSE"
+
+// writeSARIF writes violations as a SARIF 2.1.0 log
+func writeSARIF(w io.Writer, violations []v2.Violation, source v2.SourceMap) error {
+	results := lo.Map(violations, func(v v2.Violation, _ int) sarifResult {
+		result := sarifResult{
+			RuleID:  v.Rule,
+			Level:   string(v.Severity),
+			Message: sarifMessage{Text: v.Error()},
+		}
+		if loc, ok := source.Locate(v); ok {
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: sarifURI(loc.File)},
+					Region:           &sarifRegion{StartLine: loc.Line, StartColumn: loc.Column},
+				},
+			}}
+		}
+		return result
+	})
+
+	return writeSARIFResults(w, results)
+}
+
+// writeSARIFParseError writes a SARIF 2.1.0 log with a single result for a ledger file
+// that could not be parsed, so that CI consumers still get a log to upload
+func writeSARIFParseError(w io.Writer, path string, err error) error {
+	result := sarifResult{
+		RuleID:  sarifParseRule,
+		Level:   string(v2.SeverityError),
+		Message: sarifMessage{Text: err.Error()},
+	}
+	if path != v2.StdioPath {
+		result.Locations = []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifURI(path)}},
+		}}
+	}
+
+	return writeSARIFResults(w, []sarifResult{result})
+}
+
+// writeSARIFResults writes results as a SARIF 2.1.0 log describing every OLF rule
+func writeSARIFResults(w io.Writer, results []sarifResult) error {
+	ruleIDs := lo.Keys(v2.Rules)
+	sort.Strings(ruleIDs)
+
+	rules := lo.Map(ruleIDs, func(id string, _ int) sarifRule {
+		return sarifRule{ID: id, ShortDescription: sarifMessage{Text: v2.Rules[id]}}
+	})
+	rules = append(rules, sarifRule{
+		ID:               sarifParseRule,
+		ShortDescription: sarifMessage{Text: "The ledger file must be valid YAML, JSON or TOML matching the OLF v2.0 structure"},
+	})
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "ledger",
+				Version:        version,
+				InformationURI: "https://github.com/askolesov/ledger",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifURI returns a file as a URI reference: a relative path for files below the
+// working directory, as code-scanning tools expect, and a file: URI otherwise
+func sarifURI(file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return (&url.URL{Path: filepath.ToSlash(file)}).String()
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		rel, err := filepath.Rel(wd, abs)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return (&url.URL{Path: filepath.ToSlash(rel)}).String()
+		}
+	}
+
+	// Windows paths such as C:/ledger.yaml need a leading slash to form file:///C:/ledger.yaml
+	path := filepath.ToSlash(abs)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+
+	"github.com/samber/lo"
+)
+
+// v2TagTable computes a tag × month table of non-internal entries.
+// Tags are listed by total, largest expenses first, with untagged entries last.
+func v2TagTable(ledger v2.Ledger) report.Table {
+	type column struct {
+		title  string
+		totals map[string]int
+	}
+
+	var columns []column
+	totals := make(map[string]int)
+	for _, ym := range ledger.GetMonths() {
+		monthTotals := ledger.Years[ym.Year].Months[ym.Month].TagTotals()
+		columns = append(columns, column{title: ym.String(), totals: monthTotals})
+		for tag, amount := range monthTotals {
+			totals[tag] += amount
+		}
+	}
+
+	var t report.Table
+	t.AddColumn("Tag")
+	for _, c := range columns {
+		t.AddNumberColumn(c.title)
+	}
+	t.AddNumberColumn("Total")
+
+	for _, tag := range v2.SortTags(totals) {
+		cells := []any{v2.TagLabel(tag)}
+		for _, c := range columns {
+			cells = append(cells, report.Amount(c.totals[tag]))
+		}
+		t.AddRow(append(cells, report.Amount(totals[tag]))...)
+	}
+
+	footer := []any{"Total"}
+	for _, c := range columns {
+		footer = append(footer, report.Amount(lo.Sum(lo.Values(c.totals))))
+	}
+	t.SetFooter(append(footer, report.Amount(lo.Sum(lo.Values(totals))))...)
+
+	return t
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+func getTopCmd() *cobra.Command {
+	var format string
+	var n int
+	var excludeInternal bool
+	var byTag bool
+	var period periodFlags
+
+	cmd := &cobra.Command{
+		Use:   "top <file>",
+		Short: "List the largest expenses and incomes of OLF v2.0 file",
+		Long: `List the largest expenses and incomes of OLF v2.0 file.
+
+Entries of all accounts are ranked by amount, showing the --n largest
+expenses and the --n largest incomes with their date (or month for entries
+without a date), account, tag and note.
+
+With --exclude-internal, internal transfers between accounts are left out,
+as in the income and expenses of the other reports. With --by-tag, the --n
+largest entries of every tag are listed, tags with the largest totals first.
+
+Examples:
+  ledger top ledger.yaml --n 20 --period 2025        # Top 20 of 2025
+  ledger top ledger.yaml --period 2025-03            # Review a single month
+  ledger top ledger.yaml --last 12m --exclude-internal
+  ledger top ledger.yaml --by-tag --n 3              # Top 3 per tag`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			reportFormat, err := parseReportFormat(format)
+			if err != nil {
+				return err
+			}
+			if n < 1 {
+				return fmt.Errorf("--n must be at least 1")
+			}
+			cmd.SilenceUsage = true
+
+			_, ledger, currency, err := loadReport(cmd, path, &period)
+			if err != nil {
+				return err
+			}
+
+			entries := ledger.Entries()
+			if excludeInternal {
+				entries = lo.Reject(entries, func(e v2.LedgerEntry, _ int) bool { return e.Internal })
+			}
+
+			return renderReports(cmd, topTables(entries, n, byTag), reportFormat, currency)
+		},
+	}
+
+	cmd.Flags().IntVar(&n, "n", 10, "Number of entries listed, per tag with --by-tag")
+	cmd.Flags().BoolVar(&excludeInternal, "exclude-internal", false, "Leave out internal transfers between accounts")
+	cmd.Flags().BoolVar(&byTag, "by-tag", false, "List the largest entries of every tag")
+	addPeriodFlags(cmd, &period)
+	addReportFormatFlag(cmd, &format)
+
+	return cmd
+}
+
+// topTables lists the n largest expenses and incomes, optionally per tag
+func topTables(entries []v2.LedgerEntry, n int, byTag bool) []report.Table {
+	if !byTag {
+		expenses, incomes := v2.LargestEntries(entries, n)
+		return []report.Table{
+			topTable(fmt.Sprintf("Largest expenses (top %d)", n), [][]v2.LedgerEntry{expenses}),
+			topTable(fmt.Sprintf("Largest incomes (top %d)", n), [][]v2.LedgerEntry{incomes}),
+		}
+	}
+
+	expenses, incomes := v2.LargestEntries(entries, 0)
+	return []report.Table{
+		topTable(fmt.Sprintf("Largest expenses by tag (top %d per tag)", n), groupByTag(expenses, n, 1)),
+		topTable(fmt.Sprintf("Largest incomes by tag (top %d per tag)", n), groupByTag(incomes, n, -1)),
+	}
+}
+
+// groupByTag splits ranked entries into the n largest of every tag. Tags are ordered by
+// their total times sign, smallest first, so a sign of -1 lists the largest incomes first.
+func groupByTag(entries []v2.LedgerEntry, n int, sign int) [][]v2.LedgerEntry {
+	groups := lo.GroupBy(entries, func(e v2.LedgerEntry) string { return e.Tag })
+	totals := lo.MapValues(groups, func(entries []v2.LedgerEntry, _ string) int {
+		return sign * lo.SumBy(entries, func(e v2.LedgerEntry) int { return e.Amount })
+	})
+
+	return lo.Map(v2.SortTags(totals), func(tag string, _ int) []v2.LedgerEntry {
+		return groups[tag][:min(n, len(groups[tag]))]
+	})
+}
+
+// topTable lists groups of ranked entries, ranks restarting with every group
+func topTable(title string, groups [][]v2.LedgerEntry) report.Table {
+	t := report.Table{Title: title}
+	t.AddNumberColumn("#")
+	t.AddColumn("Date")
+	t.AddColumn("Account")
+	t.AddColumn("Tag")
+	t.AddColumn("Note")
+	t.AddColumn("Internal")
+	t.AddNumberColumn("Amount")
+
+	total := 0
+	for i, group := range groups {
+		for rank, e := range group {
+			internal := ""
+			if e.Internal {
+				internal = "yes"
+			}
+			cells := []any{rank + 1, e.GetDate(), e.Account, e.GetTag(), e.Note, internal, report.Amount(e.Amount)}
+			t.Rows = append(t.Rows, report.Row{Cells: cells, Separator: i > 0 && rank == 0})
+			total += e.Amount
+		}
+	}
+	t.SetFooter("Total", nil, nil, nil, nil, nil, report.Amount(total))
+
+	return t
+}
@@ -0,0 +1,160 @@
+package command
+
+import (
+	"fmt"
+	v2 "ledger/pkg/ledger/v2"
+	"ledger/pkg/report"
+	"sort"
+	"strconv"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// transferGroupings are the supported values of the --per flag of the transfers command
+var transferGroupings = []string{"month", "year", "all"}
+
+func getTransfersCmd() *cobra.Command {
+	var format string
+	var per string
+	var period periodFlags
+
+	cmd := &cobra.Command{
+		Use:   "transfers <file>",
+		Short: "Show money moved between accounts of OLF v2.0 file",
+		Long: `Show money moved between accounts of OLF v2.0 file.
+
+Internal entries of every month are paired into transfers: each outgoing entry
+is matched with an incoming entry of the same amount in another account. The
+closest candidates are paired first, by days apart and then by note, so a
+loose match never takes the counterpart of another transfer. The transfers are
+summed into a from × to account matrix per month, per year or for the whole
+selected period (--per).
+
+Internal entries without a counterpart, e.g. a transfer recorded in only one
+account or with different amounts on both sides, are listed separately.
+
+Examples:
+  ledger transfers ledger.yaml                   # Matrix per year
+  ledger transfers ledger.yaml --per month --last 6m
+  ledger transfers ledger.yaml --per all --period 2025`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			reportFormat, err := parseReportFormat(format)
+			if err != nil {
+				return err
+			}
+			if !lo.Contains(transferGroupings, per) {
+				return fmt.Errorf("unsupported --per %q, expected one of %v", per, transferGroupings)
+			}
+			cmd.SilenceUsage = true
+
+			_, ledger, currency, err := loadReport(cmd, path, &period)
+			if err != nil {
+				return err
+			}
+
+			transfers, unpaired := ledger.Transfers()
+
+			tables := transferMatrices(transfers, per)
+			tables = append(tables, unpairedTable(unpaired))
+
+			return renderReports(cmd, tables, reportFormat, currency)
+		},
+	}
+
+	cmd.Flags().StringVar(&per, "per", "year", "Group transfers per month, year or all")
+	addPeriodFlags(cmd, &period)
+	addReportFormatFlag(cmd, &format)
+
+	return cmd
+}
+
+// transferMatrices sums the transfers into one account matrix per group, in chronological order
+func transferMatrices(transfers []v2.Transfer, per string) []report.Table {
+	group := func(t v2.Transfer) string {
+		switch per {
+		case "month":
+			return t.YearMonth.String()
+		case "year":
+			return strconv.Itoa(t.Year)
+		}
+		return "all"
+	}
+
+	groups := lo.GroupBy(transfers, group)
+	keys := lo.Uniq(lo.Map(transfers, func(t v2.Transfer, _ int) string { return group(t) }))
+
+	return lo.Map(keys, func(key string, _ int) report.Table {
+		title := "Transfers " + key
+		if per == "all" {
+			first, last := groups[key][0], groups[key][len(groups[key])-1]
+			title = fmt.Sprintf("Transfers %s to %s", first.YearMonth, last.YearMonth)
+		}
+		return transferMatrix(title, groups[key])
+	})
+}
+
+// transferMatrix lists the sum of transfers from each account (rows) to each account (columns)
+func transferMatrix(title string, transfers []v2.Transfer) report.Table {
+	accounts := lo.Uniq(append(
+		lo.Map(transfers, func(t v2.Transfer, _ int) string { return t.From }),
+		lo.Map(transfers, func(t v2.Transfer, _ int) string { return t.To })...,
+	))
+	sort.Strings(accounts)
+
+	sums := make(map[[2]string]int)
+	for _, t := range transfers {
+		sums[[2]string{t.From, t.To}] += t.Amount
+	}
+
+	t := report.Table{Title: title}
+	t.AddColumn("From \\ To")
+	for _, account := range accounts {
+		t.AddNumberColumn(account)
+	}
+	t.AddNumberColumn("Total out")
+
+	totalIn := make([]int, len(accounts))
+	for _, from := range accounts {
+		cells := []any{from}
+		totalOut := 0
+		for i, to := range accounts {
+			sum, ok := sums[[2]string{from, to}]
+			if !ok {
+				cells = append(cells, nil)
+				continue
+			}
+			cells = append(cells, report.Amount(sum))
+			totalOut += sum
+			totalIn[i] += sum
+		}
+		t.AddRow(append(cells, report.Amount(totalOut))...)
+	}
+
+	footer := []any{"Total in"}
+	for _, sum := range totalIn {
+		footer = append(footer, report.Amount(sum))
+	}
+	t.SetFooter(append(footer, report.Amount(lo.Sum(totalIn)))...)
+
+	return t
+}
+
+// unpairedTable lists the internal entries without a counterpart
+func unpairedTable(entries []v2.LedgerEntry) report.Table {
+	t := report.Table{Title: fmt.Sprintf("Unpaired internal entries (%d)", len(entries))}
+	t.AddColumn("Date")
+	t.AddColumn("Account")
+	t.AddColumn("Tag")
+	t.AddColumn("Note")
+	t.AddNumberColumn("Amount")
+
+	for _, e := range entries {
+		t.AddRow(e.GetDate(), e.Account, e.GetTag(), e.Note, report.Amount(e.Amount))
+	}
+
+	return t
+}
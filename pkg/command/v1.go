@@ -4,9 +4,8 @@ import (
 	"fmt"
 	v1 "ledger/pkg/ledger/v1"
 	v2 "ledger/pkg/ledger/v2"
-	"os"
+	"ledger/pkg/report"
 
-	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +16,7 @@ func getV1Cmd() *cobra.Command {
 		Long: `Commands for Open Ledger Format v1.0 (legacy format).
 
 Provides backward compatibility with existing OLF v1.0 ledger files.
-Supports YAML format only.`,
+Supports YAML, JSON and TOML files, or "-" to read from stdin.`,
 	}
 
 	v1Cmd.AddCommand(getV1ValidateCmd())
@@ -46,7 +45,7 @@ Examples:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			path := args[0]
 
-			data, err := v1.ReadData(path)
+			data, err := v1.ReadDataFormat(path, inputFormat(cmd))
 			if err != nil {
 				return fmt.Errorf("failed to read ledger file: %w", err)
 			}
@@ -67,6 +66,7 @@ Examples:
 
 func getV1ReportCmd() *cobra.Command {
 	var short bool
+	var format string
 
 	cmd := &cobra.Command{
 		Use:   "report <file>",
@@ -84,15 +84,23 @@ Default report shows detailed table with:
 
 Use --short flag for condensed view showing only monthly expenses.
 
+Use --format csv, json, markdown or html for other output formats.
+
 Examples:
   ledger v1 report data.yaml           # Generate detailed monthly report
+  ledger v1 report data.yaml -f markdown
   ledger v1 report data.yaml --short   # Generate condensed expense report
   ledger v1 report data.yaml -s        # Short form of --short flag`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			path := args[0]
 
-			data, err := v1.ReadData(path)
+			reportFormat, err := parseReportFormat(format)
+			if err != nil {
+				return err
+			}
+
+			data, err := v1.ReadDataFormat(path, inputFormat(cmd))
 			if err != nil {
 				return fmt.Errorf("failed to read ledger file: %w", err)
 			}
@@ -102,54 +110,77 @@ Examples:
 				return fmt.Errorf("validation failed: %w", err)
 			}
 
+			currency, err := getCurrency(cmd, v2.Currency{})
+			if err != nil {
+				return err
+			}
+
 			if short {
-				v1ShortMonthlyReport(data)
-				return nil
+				t := v1ShortMonthlyTable(data)
+				if !cmd.Flags().Changed("format") {
+					printLastColumn(cmd, t, currency)
+					return nil
+				}
+				return renderReport(cmd, t, reportFormat, currency)
 			}
 
-			v1MonthlyReport(data)
-			return nil
+			return renderReport(cmd, v1MonthlyTable(data), reportFormat, currency)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&short, "short", "s", false, "Generate condensed report showing only monthly expenses")
+	addReportFormatFlag(cmd, &format)
 
 	return cmd
 }
 
-func v1MonthlyReport(d v1.Data) {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Year", "Month", "Opening", "Income", "Expenses", "Closing"})
+// v1MonthlyTable computes the income, expenses and balances of every month
+func v1MonthlyTable(d v1.Data) report.Table {
+	var t report.Table
+	t.AddNumberColumn("Year")
+	t.AddNumberColumn("Month")
+	t.AddNumberColumn("Opening")
+	t.AddNumberColumn("Income")
+	t.AddNumberColumn("Expenses")
+	t.AddNumberColumn("Closing")
 
 	for _, year := range d.Years {
-		t.AppendSeparator()
-
-		for _, month := range year.Months {
-			t.AppendRow(table.Row{
+		for i, month := range year.Months {
+			cells := []any{
 				year.Number,
 				month.Number,
-				float64(month.StartingBalance) / 1000,
-				float64(month.Income()) / 1000,
-				float64(month.Expenses()) / 1000,
-				float64(month.EndingBalance) / 1000,
-			})
+				report.Amount(month.StartingBalance),
+				report.Amount(month.Income()),
+				report.Amount(month.Expenses()),
+				report.Amount(month.EndingBalance),
+			}
+			t.Rows = append(t.Rows, report.Row{Cells: cells, Separator: i == 0})
 		}
 	}
 
-	t.Render()
+	return t
 }
 
-func v1ShortMonthlyReport(d v1.Data) {
+// v1ShortMonthlyTable computes the expenses of every month
+func v1ShortMonthlyTable(d v1.Data) report.Table {
+	var t report.Table
+	t.AddNumberColumn("Year")
+	t.AddNumberColumn("Month")
+	t.AddNumberColumn("Expenses")
+
 	for _, year := range d.Years {
 		for _, month := range year.Months {
-			fmt.Printf("%.1f\n", float64(month.Expenses())/1000)
+			t.AddRow(year.Number, month.Number, report.Amount(month.Expenses()))
 		}
 	}
+
+	return t
 }
 
 func getV1MigrateCmd() *cobra.Command {
-	return &cobra.Command{
+	var noBackup bool
+
+	cmd := &cobra.Command{
 		Use:   "migrate <input-file> <output-file>",
 		Short: "Migrate OLF v1.0 file to OLF v2.0 format",
 		Long: `Migrate OLF v1.0 file to OLF v2.0 format.
@@ -164,23 +195,32 @@ The migration process:
 - Preserves all financial data and balances
 - Validates the converted v2.0 data before writing
 
+An existing output file is replaced atomically and kept as <output-file>.bak.1
+(up to 3 rotating backups) unless --no-backup is given.
+
 Examples:
   ledger v1 migrate data-v1.yaml data-v2.yaml    # Migrate YAML file
-  ledger v1 migrate data-v1.json data-v2.json    # Migrate JSON file`,
+  ledger v1 migrate data-v1.json data-v2.json    # Migrate JSON file
+  ledger v1 migrate data-v1.toml data-v2.toml    # Migrate TOML file
+  ledger v1 migrate data-v1.yaml - --output-format json   # Print v2.0 JSON to stdout`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inputPath := args[0]
 			outputPath := args[1]
 
-			return migrateV1ToV2(cmd, inputPath, outputPath)
+			return migrateV1ToV2(cmd, inputPath, outputPath, writeOptions(cmd, noBackup))
 		},
 	}
+
+	addBackupFlag(cmd, &noBackup)
+
+	return cmd
 }
 
-func migrateV1ToV2(cmd *cobra.Command, inputPath, outputPath string) error {
+func migrateV1ToV2(cmd *cobra.Command, inputPath, outputPath string, opts v2.WriteOptions) error {
 	// Read and validate v1 data
 	cmd.Printf("Reading v1.0 ledger file: %s\n", inputPath)
-	v1Data, err := v1.ReadData(inputPath)
+	v1Data, err := v1.ReadDataFormat(inputPath, inputFormat(cmd))
 	if err != nil {
 		return fmt.Errorf("failed to read v1.0 ledger file: %w", err)
 	}
@@ -204,7 +244,7 @@ func migrateV1ToV2(cmd *cobra.Command, inputPath, outputPath string) error {
 
 	// Write v2 data
 	cmd.Printf("Writing v2.0 ledger file: %s\n", outputPath)
-	err = v2.WriteLedger(v2Ledger, outputPath)
+	err = v2.WriteLedgerWithOptions(v2Ledger, outputPath, opts)
 	if err != nil {
 		return fmt.Errorf("failed to write v2.0 ledger file: %w", err)
 	}
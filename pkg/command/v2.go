@@ -1,63 +1,121 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	v2 "ledger/pkg/ledger/v2"
-	"os"
-	"sort"
+	"ledger/pkg/report"
 
-	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
 func getV2ValidateCmd() *cobra.Command {
-	return &cobra.Command{
+	var output string
+
+	cmd := &cobra.Command{
 		Use:   "validate <file>",
 		Short: "Validate OLF v2.0 file for structural correctness and data integrity",
 		Long: `Validate OLF v2.0 file for structural correctness and data integrity.
 
+Walks the whole ledger and reports every violated rule, grouped by
+year, month and account, with the file:line:col of the offending value.
 Performs comprehensive validation including:
-- YAML/JSON structure validation
+- YAML/JSON/TOML structure validation
 - Data type validation
 - Balance calculations and consistency checks
 - Double-entry bookkeeping constraints
 - Account continuity validation
 - Cross-period balance verification
 
+Use --output json or --output sarif for machine-readable results, e.g. in
+pre-commit hooks or as code-scanning annotations in CI. A SARIF log is also
+written when the file cannot be parsed, with the error as a PARSE result.
+
+Exit codes:
+  0  ledger is valid
+  1  other error, e.g. invalid arguments
+  2  ledger violates OLF invariants
+  3  ledger file could not be parsed
+  4  ledger file could not be read
+
 Examples:
   ledger validate ledger.yaml          # Validate OLF v2.0 YAML file
   ledger validate ledger.json          # Validate OLF v2.0 JSON file
+  ledger validate ledger.yaml -o json  # Print violations as JSON
+  ledger validate ledger.yaml -o sarif > results.sarif
+  gpg -d ledger.json.gpg | ledger validate -   # Validate ledger from stdin
   ledger validate /path/to/ledger.yaml`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			path := args[0]
 
-			ledger, err := v2.ReadLedger(path)
-			if err != nil {
-				return fmt.Errorf("failed to read ledger file: %w", err)
+			if output != "text" && output != "json" && output != "sarif" {
+				return fmt.Errorf("unsupported output format: %s", output)
 			}
+			cmd.SilenceUsage = true
 
-			cmd.Printf("Successfully loaded ledger with %d year(s)\n", len(ledger.Years))
+			ledger, source, err := readLedger(cmd, path)
+			if err != nil {
+				err = fmt.Errorf("failed to read ledger file: %w", err)
+				if output == "sarif" && ExitCode(err) == ExitParseError {
+					if writeErr := writeSARIFParseError(cmd.OutOrStdout(), path, err); writeErr != nil {
+						return fmt.Errorf("failed to write validation results: %w", writeErr)
+					}
+				}
+				return err
+			}
 
-			err = ledger.Validate()
+			violations := ledger.ValidateAll()
+
+			switch output {
+			case "json":
+				err = writeValidationJSON(cmd.OutOrStdout(), path, violations, source)
+			case "sarif":
+				err = writeSARIF(cmd.OutOrStdout(), violations, source)
+			default:
+				cmd.Printf("Successfully loaded ledger with %d year(s)\n", len(ledger.Years))
+				if len(violations) > 0 {
+					printViolations(cmd, violations, source)
+				}
+			}
 			if err != nil {
-				return fmt.Errorf("validation failed: %w", err)
+				return fmt.Errorf("failed to write validation results: %w", err)
+			}
+
+			if len(violations) > 0 {
+				return violationsError{count: len(violations)}
 			}
 
-			cmd.Println("✓ Ledger is valid according to OLF v2.0 specification")
+			if output == "text" {
+				cmd.Println("✓ Ledger is valid according to OLF v2.0 specification")
 
-			// Print summary statistics
-			cmd.Printf("Total Income: %.2f\n", float64(ledger.Income())/1000)
-			cmd.Printf("Total Expenses: %.2f\n", float64(ledger.Expenses())/1000)
+				// Print summary statistics
+				currency, err := getCurrency(cmd, ledger.GetCurrency())
+				if err != nil {
+					return err
+				}
+				cmd.Printf("Total Income: %s\n", currency.Format(ledger.Income()))
+				cmd.Printf("Total Expenses: %s\n", currency.Format(ledger.Expenses()))
+			}
 
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text, json or sarif")
+
+	return cmd
 }
 
 func getV2ReportCmd() *cobra.Command {
 	var short bool
+	var by string
+	var account string
+	var format string
+	var yoy bool
+	var period periodFlags
 
 	cmd := &cobra.Command{
 		Use:   "report <file>",
@@ -75,85 +133,266 @@ Default report shows detailed table with:
 
 Use --short flag for condensed view showing only monthly expenses.
 
+Use --by tag for a tag × month table of non-internal entries, showing where
+the money goes, with totals per tag and per month. Entries without a tag are
+listed as "(untagged)".
+
+Use --by account for the opening balance, inflows, outflows, internal
+transfers and closing balance of every account per month. Accounts that are
+new (A-3) or omitted from the following month (A-4) are marked as opened or
+closed. Add --account to show the full history of a single account.
+
+Use --yoy to compare the latest year, or the year given with --year, against
+the previous year month by month. See "ledger compare" for details.
+
+Select the reported months with --from and --to (YYYY-MM), --year, or
+relative to the latest month of the ledger with --last (e.g. 12m, 2y) or --ytd.
+The totals use the opening balance of the first and the closing balance of
+the last month of the period.
+
+Amounts are displayed according to the ledger's currency section, or with
+--scale, --currency, --decimals and the separator flags, e.g. --scale 1 for
+ledgers kept in whole units.
+
+Use --format csv, json, markdown or html to paste reports into spreadsheets
+or wiki pages. CSV and JSON contain plain numbers without symbol and separators.
+With --short, the bare expense numbers are printed unless --format is given.
+
 Examples:
   ledger report ledger.yaml            # Generate detailed monthly report
+  ledger report ledger.yaml --format csv > report.csv
+  ledger report ledger.yaml --scale 100 --currency '$' --thousands-separator ,
   ledger report ledger.json --short    # Generate condensed expense report
-  ledger report ledger.yaml -s         # Short form of --short flag`,
+  ledger report ledger.yaml -s         # Short form of --short flag
+  ledger report ledger.yaml --by tag --year 2025   # Spending per tag in 2025
+  ledger report ledger.yaml --from 2024-03 --to 2025-02
+  ledger report ledger.yaml --last 12m # Last 12 months of the ledger
+  ledger report ledger.yaml --yoy --year 2025      # 2025 against 2024
+  ledger report ledger.yaml --by account --account Savings
+  ledger report - --input-format toml < ledger.toml`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			path := args[0]
 
-			ledger, err := v2.ReadLedger(path)
+			if by != "month" && by != "tag" && by != "account" {
+				return fmt.Errorf("unsupported report grouping: %s", by)
+			}
+			if account != "" && by != "account" {
+				return fmt.Errorf("--account requires --by account")
+			}
+			if yoy && (by != "month" || short) {
+				return fmt.Errorf("--yoy cannot be combined with --by or --short")
+			}
+			reportFormat, err := parseReportFormat(format)
 			if err != nil {
-				return fmt.Errorf("failed to read ledger file: %w", err)
+				return err
 			}
 
-			err = ledger.Validate()
+			ledger, filtered, currency, err := loadReport(cmd, path, &period)
 			if err != nil {
-				return fmt.Errorf("validation failed: %w", err)
+				return err
 			}
 
-			if short {
-				v2ShortMonthlyReport(ledger)
-				return nil
+			if yoy {
+				year := period.year
+				if year == 0 {
+					year = lo.Max(ledger.GetYearNumbers())
+				}
+				tables, err := compareTables(ledger, year-1, year)
+				if err != nil {
+					return err
+				}
+				return renderReports(cmd, tables, reportFormat, currency)
 			}
 
-			v2MonthlyReport(ledger)
-			return nil
+			history := ledger.AccountHistory()
+
+			var t report.Table
+			switch {
+			case by == "account":
+				t, err = v2AccountTable(history, filtered, account)
+				if err != nil {
+					return err
+				}
+			case by == "tag":
+				t = v2TagTable(filtered)
+			case short:
+				t = v2ShortMonthlyTable(filtered)
+				if !cmd.Flags().Changed("format") {
+					printLastColumn(cmd, t, currency)
+					return nil
+				}
+			default:
+				t = v2MonthlyTable(filtered)
+			}
+
+			return renderReport(cmd, t, reportFormat, currency)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&short, "short", "s", false, "Generate condensed report showing only monthly expenses")
+	cmd.Flags().StringVar(&by, "by", "month", "Group the report by month, tag or account")
+	cmd.Flags().StringVar(&account, "account", "", "Show the history of a single account (with --by account)")
+	cmd.Flags().BoolVar(&yoy, "yoy", false, "Compare a year against the previous year month by month")
+	addPeriodFlags(cmd, &period)
+	addReportFormatFlag(cmd, &format)
+	cmd.MarkFlagsMutuallyExclusive("yoy", "from")
+	cmd.MarkFlagsMutuallyExclusive("yoy", "to")
+	cmd.MarkFlagsMutuallyExclusive("yoy", "last")
+	cmd.MarkFlagsMutuallyExclusive("yoy", "ytd")
+	cmd.MarkFlagsMutuallyExclusive("yoy", "period")
 
 	return cmd
 }
 
-func v2MonthlyReport(ledger v2.Ledger) {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Year", "Month", "Opening", "Income", "Expenses", "Closing"})
-
-	// Get sorted year numbers
-	yearNums := lo.Keys(ledger.Years)
-	sort.Ints(yearNums)
-
-	for _, yearNum := range yearNums {
+// v2MonthlyTable computes the income, expenses and balances of every month
+func v2MonthlyTable(ledger v2.Ledger) report.Table {
+	var t report.Table
+	t.AddNumberColumn("Year")
+	t.AddNumberColumn("Month")
+	t.AddNumberColumn("Opening")
+	t.AddNumberColumn("Income")
+	t.AddNumberColumn("Expenses")
+	t.AddNumberColumn("Closing")
+
+	for _, yearNum := range ledger.GetYearNumbers() {
 		year := ledger.Years[yearNum]
-		t.AppendSeparator()
 
-		// Get sorted month numbers for this year
-		monthNums := year.GetMonthNumbers()
-
-		for _, monthNum := range monthNums {
+		for i, monthNum := range year.GetMonthNumbers() {
 			month := year.Months[monthNum]
-			t.AppendRow(table.Row{
+			cells := []any{
 				yearNum,
 				monthNum,
-				float64(month.OpeningBalance) / 1000,
-				float64(month.Income()) / 1000,
-				float64(month.Expenses()) / 1000,
-				float64(month.ClosingBalance) / 1000,
-			})
+				report.Amount(month.OpeningBalance),
+				report.Amount(month.Income()),
+				report.Amount(month.Expenses()),
+				report.Amount(month.ClosingBalance),
+			}
+			t.Rows = append(t.Rows, report.Row{Cells: cells, Separator: i == 0})
 		}
 	}
 
-	t.Render()
+	t.SetFooter(
+		"Total",
+		nil,
+		report.Amount(ledger.OpeningBalance()),
+		report.Amount(ledger.Income()),
+		report.Amount(ledger.Expenses()),
+		report.Amount(ledger.ClosingBalance()),
+	)
+
+	return t
 }
 
-func v2ShortMonthlyReport(ledger v2.Ledger) {
-	// Get sorted year numbers
-	yearNums := lo.Keys(ledger.Years)
-	sort.Ints(yearNums)
+// v2ShortMonthlyTable computes the expenses of every month
+func v2ShortMonthlyTable(ledger v2.Ledger) report.Table {
+	var t report.Table
+	t.AddNumberColumn("Year")
+	t.AddNumberColumn("Month")
+	t.AddNumberColumn("Expenses")
 
-	for _, yearNum := range yearNums {
+	for _, yearNum := range ledger.GetYearNumbers() {
 		year := ledger.Years[yearNum]
+		for _, monthNum := range year.GetMonthNumbers() {
+			t.AddRow(yearNum, monthNum, report.Amount(year.Months[monthNum].Expenses()))
+		}
+	}
 
-		// Get sorted month numbers for this year
-		monthNums := year.GetMonthNumbers()
+	return t
+}
 
-		for _, monthNum := range monthNums {
-			month := year.Months[monthNum]
-			fmt.Printf("%.1f\n", float64(month.Expenses())/1000)
+// printViolations prints validation violations grouped by year, month and account.
+// Violations are expected in validation order, which already walks years and months in order.
+// Violations of a year or month itself are printed before those of its months or accounts.
+// Budget violations are printed last, after the years.
+// Each violation is prefixed with its file:line:col location when it is known.
+func printViolations(cmd *cobra.Command, violations []v2.Violation, source v2.SourceMap) {
+	cmd.Printf("✗ Found %d violation(s):\n", len(violations))
+
+	isBudget := func(v v2.Violation, _ int) bool { return v.Path.Budget != nil }
+	budgetViolations, ledgerViolations := lo.Filter(violations, isBudget), lo.Reject(violations, isBudget)
+
+	for _, year := range groupKeys(lo.Map(ledgerViolations, func(v v2.Violation, _ int) int { return v.Path.Year })) {
+		yearViolations := lo.Filter(ledgerViolations, func(v v2.Violation, _ int) bool { return v.Path.Year == year })
+		cmd.Printf("year %d:\n", year)
+
+		for _, month := range groupKeys(lo.Map(yearViolations, func(v v2.Violation, _ int) int { return v.Path.Month })) {
+			monthViolations := lo.Filter(yearViolations, func(v v2.Violation, _ int) bool { return v.Path.Month == month })
+			indent := "  "
+			if month != 0 {
+				cmd.Printf("  month %d:\n", month)
+				indent = "    "
+			}
+
+			for _, account := range groupKeys(lo.Map(monthViolations, func(v v2.Violation, _ int) string { return v.Path.Account })) {
+				accountViolations := lo.Filter(monthViolations, func(v v2.Violation, _ int) bool { return v.Path.Account == account })
+				accountIndent := indent
+				if account != "" {
+					cmd.Printf("%saccount %s:\n", indent, account)
+					accountIndent += "  "
+				}
+
+				for _, v := range accountViolations {
+					message := v.Message
+					if v.Path.Entry != nil {
+						message = fmt.Sprintf("entry %d: %s", *v.Path.Entry, message)
+					}
+					if loc, ok := source.Locate(v); ok {
+						message = fmt.Sprintf("%s: %s", loc, message)
+					}
+					cmd.Printf("%s- %s\n", accountIndent, message)
+				}
+			}
+		}
+	}
+
+	if len(budgetViolations) > 0 {
+		cmd.Println("budgets:")
+	}
+	for _, v := range budgetViolations {
+		message := fmt.Sprintf("budget %d: %s", *v.Path.Budget, v.Message)
+		if loc, ok := source.Locate(v); ok {
+			message = fmt.Sprintf("%s: %s", loc, message)
 		}
+		cmd.Printf("  - %s\n", message)
+	}
+}
+
+// validationViolation is a violation together with its source location, as written by --output json
+type validationViolation struct {
+	v2.Violation
+	Location *v2.Location `json:"location,omitempty"`
+}
+
+// writeValidationJSON writes validation results as a JSON document
+func writeValidationJSON(w io.Writer, path string, violations []v2.Violation, source v2.SourceMap) error {
+	result := struct {
+		File       string                `json:"file"`
+		Valid      bool                  `json:"valid"`
+		Violations []validationViolation `json:"violations"`
+	}{
+		File:  path,
+		Valid: len(violations) == 0,
+		Violations: lo.Map(violations, func(v v2.Violation, _ int) validationViolation {
+			item := validationViolation{Violation: v}
+			if loc, ok := source.Locate(v); ok {
+				item.Location = &loc
+			}
+			return item
+		}),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// groupKeys returns unique keys in order of first appearance, with the zero key (the enclosing level) first
+func groupKeys[T comparable](keys []T) []T {
+	var zero T
+	keys = lo.Uniq(keys)
+	if lo.Contains(keys, zero) {
+		keys = append([]T{zero}, lo.Without(keys, zero)...)
 	}
+	return keys
 }
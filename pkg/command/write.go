@@ -0,0 +1,25 @@
+package command
+
+import (
+	v2 "ledger/pkg/ledger/v2"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultBackups is the number of rotating backups kept by commands that overwrite a ledger file
+const defaultBackups = 3
+
+// addBackupFlag registers the --no-backup flag shared by every command that writes a ledger file
+func addBackupFlag(cmd *cobra.Command, noBackup *bool) {
+	cmd.Flags().BoolVar(noBackup, "no-backup", false, "Do not keep backups (<file>.bak.N) of overwritten ledger files")
+}
+
+// writeOptions returns the options for writing a ledger file according to the --no-backup
+// and --output-format flags
+func writeOptions(cmd *cobra.Command, noBackup bool) v2.WriteOptions {
+	opts := v2.WriteOptions{Format: v2.Format(outputFormat(cmd))}
+	if !noBackup {
+		opts.Backups = defaultBackups
+	}
+	return opts
+}
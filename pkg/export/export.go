@@ -0,0 +1,92 @@
+// Package export generates static, self-contained views of a ledger for sharing
+package export
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	v2 "ledger/pkg/ledger/v2"
+	"os"
+	"path/filepath"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// Page file names of the generated site
+const (
+	indexPage = "index.html"
+	tagsPage  = "tags.html"
+)
+
+// monthPageName returns the file name of a month's page, e.g. 2025-01.html
+func monthPageName(ym v2.YearMonth) string {
+	return ym.String() + ".html"
+}
+
+// HTML writes a static site of the ledger into dir: an overview with yearly totals and net worth,
+// a drill-down page per month with its accounts and entries, and a tag breakdown.
+// Pages link to each other relatively and carry their styles and SVG charts inline,
+// so the directory can be opened from disk or hosted anywhere as is.
+func HTML(ledger v2.Ledger, currency v2.Currency, dir string) error {
+	templates, err := template.New("").Funcs(template.FuncMap{
+		"amount": currency.Format,
+		"neg": func(amount int) string {
+			if amount < 0 {
+				return " neg"
+			}
+			return ""
+		},
+	}).ParseFS(templateFS, "templates/*.html")
+	if err != nil {
+		return fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	pages := []page{
+		{file: indexPage, template: "index.html", data: newOverview(ledger, currency)},
+		{file: tagsPage, template: "tags.html", data: newTagBreakdown(ledger, currency)},
+	}
+	months := ledger.GetMonths()
+	for i, ym := range months {
+		pages = append(pages, page{
+			file:     monthPageName(ym),
+			template: "month.html",
+			data:     newMonthDetail(ledger, currency, months, i),
+		})
+	}
+
+	for _, p := range pages {
+		if err := p.write(templates, dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// page is a file of the generated site and the template rendering it
+type page struct {
+	file     string
+	template string
+	data     any
+}
+
+// write executes the page's template into its file in dir
+func (p page) write(templates *template.Template, dir string) error {
+	path := filepath.Join(dir, p.file)
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if err := templates.ExecuteTemplate(f, p.template, p.data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,71 @@
+package export
+
+import (
+	v2 "ledger/pkg/ledger/v2"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLedger() v2.Ledger {
+	return v2.Ledger{Years: map[int]v2.Year{
+		2025: {OpeningBalance: 100, ClosingBalance: 160, Months: map[int]v2.Month{
+			1: {OpeningBalance: 100, ClosingBalance: 130, Accounts: map[string]v2.Account{
+				"Checking": {OpeningBalance: 100, ClosingBalance: 130, Entries: []v2.Entry{
+					{Amount: 50, Note: "Salary", Date: "2025-01-28", Tag: "Income"},
+					{Amount: -20, Note: "Groceries <weekly>", Tag: "Food"},
+				}},
+			}},
+			2: {OpeningBalance: 130, ClosingBalance: 160, Accounts: map[string]v2.Account{
+				"Checking": {OpeningBalance: 130, ClosingBalance: 160, Entries: []v2.Entry{
+					{Amount: 50, Note: "Salary", Tag: "Income"},
+					{Amount: -20, Note: "Groceries", Tag: "Food"},
+				}},
+			}},
+		}},
+	}}
+}
+
+func TestHTML(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "site")
+	currency := v2.Currency{Scale: 1, Symbol: "$"}
+
+	require.NoError(t, HTML(testLedger(), currency, dir))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.ElementsMatch(t, []string{"index.html", "tags.html", "2025-01.html", "2025-02.html"}, names)
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	index := read("index.html")
+	assert.Contains(t, index, `<a href="2025-02.html">2025-02</a>`)
+	assert.Contains(t, index, `<td class="num">$160.00</td>`)
+	assert.Contains(t, index, `<polyline class="line"`)
+
+	month := read("2025-01.html")
+	assert.Contains(t, month, `<a href="2025-02.html">2025-02 →</a>`)
+	assert.Contains(t, month, "Groceries &lt;weekly&gt;")
+	assert.Contains(t, month, `<td class="num neg">-$20.00</td>`)
+	assert.Contains(t, month, "<title>Food: -$20.00</title>")
+
+	tags := read("tags.html")
+	assert.Contains(t, tags, `<tr><td>Food</td><td class="num neg">-$40.00</td><td class="num neg">-$40.00</td></tr>`)
+
+	// Pages are self-contained
+	for _, name := range names {
+		assert.NotContains(t, read(name), "<script")
+		assert.NotContains(t, read(name), "http")
+	}
+}
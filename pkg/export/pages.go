@@ -0,0 +1,194 @@
+package export
+
+import (
+	"html/template"
+	v2 "ledger/pkg/ledger/v2"
+)
+
+// monthSummary is a month's totals with a link to its page
+type monthSummary struct {
+	v2.YearMonth
+	Page           string
+	OpeningBalance int
+	ClosingBalance int
+	Income         int
+	Expenses       int
+}
+
+// yearSummary is a year's totals with its months
+type yearSummary struct {
+	Year           int
+	OpeningBalance int
+	ClosingBalance int
+	Income         int
+	Expenses       int
+	Months         []monthSummary
+}
+
+// overview is the data of the index page
+type overview struct {
+	Title          string
+	NetWorth       template.HTML
+	OpeningBalance int
+	ClosingBalance int
+	Income         int
+	Expenses       int
+	Years          []yearSummary
+}
+
+// accountDetail is an account of a month with its entries
+type accountDetail struct {
+	Name string
+	v2.Account
+	Income   int
+	Expenses int
+	Internal int
+}
+
+// tagAmount is the total of a tag
+type tagAmount struct {
+	Tag    string
+	Amount int
+}
+
+// monthDetail is the data of a month's page
+type monthDetail struct {
+	Title string
+	monthSummary
+	Prev     *monthSummary
+	Next     *monthSummary
+	Accounts []accountDetail
+	Tags     []tagAmount
+	TagChart template.HTML
+}
+
+// tagRow is a tag's totals per year
+type tagRow struct {
+	Tag     string
+	Amounts []int
+	Total   int
+}
+
+// tagBreakdown is the data of the tags page
+type tagBreakdown struct {
+	Title  string
+	Chart  template.HTML
+	Years  []int
+	Rows   []tagRow
+	Totals []int
+	Total  int
+}
+
+func newMonthSummary(ledger v2.Ledger, ym v2.YearMonth) monthSummary {
+	month := ledger.Years[ym.Year].Months[ym.Month]
+	return monthSummary{
+		YearMonth:      ym,
+		Page:           monthPageName(ym),
+		OpeningBalance: month.OpeningBalance,
+		ClosingBalance: month.ClosingBalance,
+		Income:         month.Income(),
+		Expenses:       month.Expenses(),
+	}
+}
+
+func newOverview(ledger v2.Ledger, currency v2.Currency) overview {
+	var netWorth []point
+	for _, m := range ledger.NetWorth() {
+		netWorth = append(netWorth, point{Label: m.String(), Value: m.NetWorth, Text: currency.Format(m.NetWorth)})
+	}
+
+	result := overview{
+		Title:          "Overview",
+		NetWorth:       lineChart(netWorth),
+		OpeningBalance: ledger.OpeningBalance(),
+		ClosingBalance: ledger.ClosingBalance(),
+		Income:         ledger.Income(),
+		Expenses:       ledger.Expenses(),
+	}
+
+	for _, yearNum := range ledger.GetYearNumbers() {
+		year := ledger.Years[yearNum]
+		summary := yearSummary{
+			Year:           yearNum,
+			OpeningBalance: year.OpeningBalance,
+			ClosingBalance: year.ClosingBalance,
+			Income:         year.Income(),
+			Expenses:       year.Expenses(),
+		}
+		for _, monthNum := range year.GetMonthNumbers() {
+			summary.Months = append(summary.Months, newMonthSummary(ledger, v2.YearMonth{Year: yearNum, Month: monthNum}))
+		}
+		result.Years = append(result.Years, summary)
+	}
+
+	return result
+}
+
+// newMonthDetail builds the page of months[i], linking to its neighbouring months
+func newMonthDetail(ledger v2.Ledger, currency v2.Currency, months []v2.YearMonth, i int) monthDetail {
+	ym := months[i]
+	month := ledger.Years[ym.Year].Months[ym.Month]
+
+	result := monthDetail{Title: ym.String(), monthSummary: newMonthSummary(ledger, ym)}
+	if i > 0 {
+		prev := newMonthSummary(ledger, months[i-1])
+		result.Prev = &prev
+	}
+	if i < len(months)-1 {
+		next := newMonthSummary(ledger, months[i+1])
+		result.Next = &next
+	}
+
+	for _, name := range month.GetAccountNames() {
+		account := month.Accounts[name]
+		result.Accounts = append(result.Accounts, accountDetail{
+			Name:     name,
+			Account:  account,
+			Income:   account.Income(),
+			Expenses: account.Expenses(),
+			Internal: account.InternalEntriesSum(),
+		})
+	}
+
+	totals := month.TagTotals()
+	var points []point
+	for _, tag := range v2.SortTags(totals) {
+		result.Tags = append(result.Tags, tagAmount{Tag: v2.TagLabel(tag), Amount: totals[tag]})
+		points = append(points, point{Label: v2.TagLabel(tag), Value: totals[tag], Text: currency.Format(totals[tag])})
+	}
+	result.TagChart = barChart(points)
+
+	return result
+}
+
+func newTagBreakdown(ledger v2.Ledger, currency v2.Currency) tagBreakdown {
+	result := tagBreakdown{Title: "Tags", Years: ledger.GetYearNumbers()}
+
+	yearTotals := make([]map[string]int, len(result.Years))
+	totals := make(map[string]int)
+	for i, yearNum := range result.Years {
+		yearTotals[i] = make(map[string]int)
+		for _, month := range ledger.Years[yearNum].Months {
+			for tag, amount := range month.TagTotals() {
+				yearTotals[i][tag] += amount
+				totals[tag] += amount
+			}
+		}
+	}
+
+	result.Totals = make([]int, len(result.Years))
+	var points []point
+	for _, tag := range v2.SortTags(totals) {
+		row := tagRow{Tag: v2.TagLabel(tag), Total: totals[tag]}
+		for i := range result.Years {
+			row.Amounts = append(row.Amounts, yearTotals[i][tag])
+			result.Totals[i] += yearTotals[i][tag]
+		}
+		result.Rows = append(result.Rows, row)
+		result.Total += totals[tag]
+		points = append(points, point{Label: v2.TagLabel(tag), Value: totals[tag], Text: currency.Format(totals[tag])})
+	}
+	result.Chart = barChart(points)
+
+	return result
+}
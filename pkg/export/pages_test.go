@@ -0,0 +1,43 @@
+package export
+
+import (
+	v2 "ledger/pkg/ledger/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMonthDetail(t *testing.T) {
+	ledger := testLedger()
+	months := ledger.GetMonths()
+	currency := v2.Currency{Scale: 1}
+
+	first := newMonthDetail(ledger, currency, months, 0)
+	assert.Nil(t, first.Prev)
+	require.NotNil(t, first.Next)
+	assert.Equal(t, "2025-02.html", first.Next.Page)
+	assert.Equal(t, 50, first.Income)
+	assert.Equal(t, -20, first.Expenses)
+	require.Len(t, first.Accounts, 1)
+	assert.Equal(t, "Checking", first.Accounts[0].Name)
+	assert.Len(t, first.Accounts[0].Entries, 2)
+	assert.Equal(t, []tagAmount{{Tag: "Food", Amount: -20}, {Tag: "Income", Amount: 50}}, first.Tags)
+
+	last := newMonthDetail(ledger, currency, months, 1)
+	require.NotNil(t, last.Prev)
+	assert.Equal(t, "2025-01.html", last.Prev.Page)
+	assert.Nil(t, last.Next)
+}
+
+func TestNewTagBreakdown(t *testing.T) {
+	breakdown := newTagBreakdown(testLedger(), v2.Currency{Scale: 1})
+
+	assert.Equal(t, []int{2025}, breakdown.Years)
+	assert.Equal(t, []tagRow{
+		{Tag: "Food", Amounts: []int{-40}, Total: -40},
+		{Tag: "Income", Amounts: []int{100}, Total: 100},
+	}, breakdown.Rows)
+	assert.Equal(t, []int{60}, breakdown.Totals)
+	assert.Equal(t, 60, breakdown.Total)
+}
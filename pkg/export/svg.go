@@ -0,0 +1,121 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	v2 "ledger/pkg/ledger/v2"
+	"strings"
+)
+
+// Chart dimensions in SVG user units; charts scale to the page width through their viewBox
+const (
+	chartWidth   = 720
+	chartHeight  = 240
+	chartPadding = 40
+	barHeight    = 22
+	barGap       = 6
+	barLabelSize = 160
+	barValueSize = 110
+)
+
+// point is a labeled value of a chart
+type point struct {
+	Label string
+	Value int
+	// Text is the formatted value shown in tooltips and labels
+	Text string
+}
+
+// lineChart returns an inline SVG line chart of the points, with a tooltip on each point
+func lineChart(points []point) template.HTML {
+	if len(points) == 0 {
+		return ""
+	}
+
+	lo, hi := 0, 0
+	for _, p := range points {
+		lo, hi = min(lo, p.Value), max(hi, p.Value)
+	}
+	if lo == hi {
+		hi = lo + 1
+	}
+
+	plotWidth := float64(chartWidth - 2*chartPadding)
+	plotHeight := float64(chartHeight - 2*chartPadding)
+	x := func(i int) float64 {
+		if len(points) == 1 {
+			return chartPadding + plotWidth/2
+		}
+		return chartPadding + plotWidth*float64(i)/float64(len(points)-1)
+	}
+	y := func(v int) float64 {
+		return chartPadding + plotHeight*float64(hi-v)/float64(hi-lo)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg class="chart" viewBox="0 0 %d %d" role="img">`, chartWidth, chartHeight)
+	fmt.Fprintf(&sb, `<line class="axis" x1="%d" y1="%.1f" x2="%d" y2="%.1f"/>`,
+		chartPadding, y(0), chartWidth-chartPadding, y(0))
+
+	coords := make([]string, len(points))
+	for i, p := range points {
+		coords[i] = fmt.Sprintf("%.1f,%.1f", x(i), y(p.Value))
+	}
+	fmt.Fprintf(&sb, `<polyline class="line" points="%s"/>`, strings.Join(coords, " "))
+
+	for i, p := range points {
+		fmt.Fprintf(&sb, `<circle class="dot" cx="%.1f" cy="%.1f" r="3"><title>%s: %s</title></circle>`,
+			x(i), y(p.Value), html.EscapeString(p.Label), html.EscapeString(p.Text))
+	}
+
+	first, last := points[0], points[len(points)-1]
+	fmt.Fprintf(&sb, `<text x="%d" y="%d">%s</text>`, chartPadding, chartHeight-chartPadding/3, html.EscapeString(first.Label))
+	fmt.Fprintf(&sb, `<text x="%d" y="%d" text-anchor="end">%s</text>`,
+		chartWidth-chartPadding, chartHeight-chartPadding/3, html.EscapeString(last.Label))
+	fmt.Fprintf(&sb, `<text x="%.1f" y="%.1f" text-anchor="end">%s</text>`,
+		x(len(points)-1), y(last.Value)-8, html.EscapeString(last.Text))
+	sb.WriteString(`</svg>`)
+
+	return template.HTML(sb.String())
+}
+
+// barChart returns an inline SVG horizontal bar chart of the points, scaled to the largest magnitude.
+// Negative values are drawn by their size with a distinct class.
+func barChart(points []point) template.HTML {
+	if len(points) == 0 {
+		return ""
+	}
+
+	maxValue := 0
+	for _, p := range points {
+		maxValue = max(maxValue, v2.Abs(p.Value))
+	}
+
+	plotWidth := float64(chartWidth - barLabelSize - barValueSize)
+	height := len(points)*(barHeight+barGap) + barGap
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg class="chart" viewBox="0 0 %d %d" role="img">`, chartWidth, height)
+	for i, p := range points {
+		top := barGap + i*(barHeight+barGap)
+		width := 0.0
+		if maxValue > 0 {
+			width = plotWidth * float64(v2.Abs(p.Value)) / float64(maxValue)
+		}
+		class := "bar"
+		if p.Value < 0 {
+			class = "bar negative"
+		}
+
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" text-anchor="end">%s</text>`,
+			barLabelSize-8, top+barHeight*3/4, html.EscapeString(p.Label))
+		fmt.Fprintf(&sb, `<rect class="%s" x="%d" y="%d" width="%.1f" height="%d"><title>%s: %s</title></rect>`,
+			class, barLabelSize, top, width, barHeight, html.EscapeString(p.Label), html.EscapeString(p.Text))
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%d">%s</text>`,
+			float64(barLabelSize)+width+6, top+barHeight*3/4, html.EscapeString(p.Text))
+	}
+	sb.WriteString(`</svg>`)
+
+	return template.HTML(sb.String())
+}
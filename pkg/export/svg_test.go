@@ -0,0 +1,41 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineChart(t *testing.T) {
+	chart := string(lineChart([]point{
+		{Label: "2025-01", Value: 100, Text: "$1.00"},
+		{Label: "2025-02", Value: 300, Text: "$3.00"},
+		{Label: "2025-03", Value: -100, Text: "-$1.00"},
+	}))
+
+	assert.True(t, strings.HasPrefix(chart, `<svg class="chart" viewBox="0 0 720 240"`))
+	assert.Equal(t, 3, strings.Count(chart, "<circle"))
+	// Values span -100..300 over the 160 units high plot starting at y=40
+	assert.Contains(t, chart, `points="40.0,120.0 360.0,40.0 680.0,200.0"`)
+	assert.Contains(t, chart, `<line class="axis" x1="40" y1="160.0" x2="680" y2="160.0"/>`)
+	assert.Contains(t, chart, "<title>2025-03: -$1.00</title>")
+
+	assert.Empty(t, lineChart(nil))
+}
+
+func TestBarChart(t *testing.T) {
+	chart := string(barChart([]point{
+		{Label: "Rent", Value: -200, Text: "-$2.00"},
+		{Label: "<Food>", Value: 100, Text: "$1.00"},
+	}))
+
+	assert.True(t, strings.HasPrefix(chart, `<svg class="chart" viewBox="0 0 720 62"`))
+	assert.Contains(t, chart, `<rect class="bar negative" x="160" y="6" width="450.0" height="22">`)
+	assert.Contains(t, chart, `<rect class="bar" x="160" y="34" width="225.0" height="22">`)
+	// Labels are escaped
+	assert.Contains(t, chart, "&lt;Food&gt;")
+	assert.NotContains(t, chart, "<Food>")
+
+	assert.Empty(t, barChart(nil))
+}
@@ -3,9 +3,8 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"ledger/pkg/olfio"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
@@ -50,22 +49,28 @@ func (d Data) String() string {
 	return string(str)
 }
 
+// ReadData reads a v1 ledger file, detecting its format from the extension.
+// The path "-" reads from standard input, as JSON if it starts with '{' and as YAML otherwise.
 func ReadData(path string) (Data, error) {
-	bytes, err := os.ReadFile(path)
+	return ReadDataFormat(path, "")
+}
+
+// ReadDataFormat reads a v1 ledger file in the given format: yaml, yml, json or toml.
+// An empty format is detected like for OLF v2.0 files, see olfio.ReadInput.
+func ReadDataFormat(path, format string) (Data, error) {
+	bytes, detected, err := olfio.ReadInput(path, olfio.Format(format), os.Stdin)
 	if err != nil {
 		return Data{}, err
 	}
 
 	data := Data{}
-	switch strings.ToLower(filepath.Ext(path)) {
-	case ".json":
+	switch detected {
+	case olfio.JSON:
 		err = json.Unmarshal(bytes, &data)
-	case ".yaml", ".yml":
+	case olfio.YAML:
 		err = yaml.Unmarshal(bytes, &data)
-	case ".toml":
+	case olfio.TOML:
 		err = toml.Unmarshal(bytes, &data)
-	default:
-		err = fmt.Errorf("unsupported file format")
 	}
 
 	return data, err
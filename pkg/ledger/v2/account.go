@@ -1,10 +1,6 @@
 package v2
 
-import (
-	"fmt"
-
-	"github.com/samber/lo"
-)
+import "github.com/samber/lo"
 
 // Account represents a financial account with entries
 type Account struct {
@@ -15,36 +11,47 @@ type Account struct {
 
 // Validate validates an account according to OLF v2.0 rules
 func (a Account) Validate(year, month int, prevAccount *Account, hasPrevMonth bool) error {
+	return firstViolation(a.ValidateAll(year, month, prevAccount, hasPrevMonth))
+}
+
+// ValidateAll validates an account and returns every violated rule instead of stopping at the first one
+func (a Account) ValidateAll(year, month int, prevAccount *Account, hasPrevMonth bool) []Violation {
+	var violations []Violation
+
 	// Validate all entries
 	for i, entry := range a.Entries {
-		if err := entry.Validate(year, month); err != nil {
-			return fmt.Errorf("entry %d: %w", i, err)
+		for _, violation := range entry.ValidateAll(year, month) {
+			violation.Path.Entry = &i
+			violations = append(violations, violation)
 		}
 	}
 
 	// A-1: For every account: opening_balance + Σ(entry.amount) = closing_balance
 	calculatedBalance := a.OpeningBalance + a.EntriesSum()
 	if calculatedBalance != a.ClosingBalance {
-		return fmt.Errorf("A-1: account balance calculation incorrect (opening: %d + entries: %d = %d, expected closing: %d)",
-			a.OpeningBalance, a.EntriesSum(), calculatedBalance, a.ClosingBalance)
+		violations = append(violations, newViolation("A-1", calculatedBalance, a.ClosingBalance,
+			"account balance calculation incorrect (opening: %d + entries: %d = %d, expected closing: %d)",
+			a.OpeningBalance, a.EntriesSum(), calculatedBalance, a.ClosingBalance))
 	}
 
 	if hasPrevMonth { // don't check for first month
 		if prevAccount != nil {
 			// A-2: If an account exists in consecutive months, prev.closing_balance = next.opening_balance
 			if a.OpeningBalance != prevAccount.ClosingBalance {
-				return fmt.Errorf("A-2: account opening balance does not equal previous month closing balance (expected: %d, got: %d)",
-					prevAccount.ClosingBalance, a.OpeningBalance)
+				violations = append(violations, newViolation("A-2", prevAccount.ClosingBalance, a.OpeningBalance,
+					"account opening balance does not equal previous month closing balance (expected: %d, got: %d)",
+					prevAccount.ClosingBalance, a.OpeningBalance))
 			}
 		} else {
 			// A-3: A new account must start with opening_balance = 0
 			if a.OpeningBalance != 0 {
-				return fmt.Errorf("A-3: new account must start with opening balance 0 (got: %d)", a.OpeningBalance)
+				violations = append(violations, newViolation("A-3", 0, a.OpeningBalance,
+					"new account must start with opening balance 0 (got: %d)", a.OpeningBalance))
 			}
 		}
 	}
 
-	return nil
+	return violations
 }
 
 // EntriesSum returns the sum of all entry amounts
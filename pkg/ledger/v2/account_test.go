@@ -114,7 +114,7 @@ func TestAccount_Validate(t *testing.T) {
 			year:    2025,
 			month:   1,
 			wantErr: true,
-			errMsg:  "entry 0: amount: cannot be blank; note: cannot be blank",
+			errMsg:  "entry 0: E-2: amount: cannot be blank; note: cannot be blank",
 		},
 		{
 			name: "entry with year mismatch",
@@ -0,0 +1,237 @@
+package v2
+
+import (
+	"math"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// AnomalyKind is what an anomaly was found in
+type AnomalyKind string
+
+const (
+	// AnomalyExpenses is a month whose total expenses deviate from the trailing median
+	AnomalyExpenses AnomalyKind = "expenses"
+	// AnomalyTag is a month whose spend on a tag deviates from the trailing median
+	AnomalyTag AnomalyKind = "tag"
+	// AnomalyEntry is an entry much larger than the usual entries of its tag
+	AnomalyEntry AnomalyKind = "entry"
+	// AnomalyAccount is a month whose account balance changes much more than usual
+	AnomalyAccount AnomalyKind = "account"
+)
+
+// anomalyKindOrder orders anomalies of the same month
+var anomalyKindOrder = map[AnomalyKind]int{AnomalyExpenses: 0, AnomalyTag: 1, AnomalyEntry: 2, AnomalyAccount: 3}
+
+// Defaults of AnomalyOptions
+const (
+	DefaultAnomalyWindow     = 12
+	DefaultAnomalyMinHistory = 3
+	DefaultAnomalyThreshold  = 50.0
+	DefaultAnomalyFactor     = 3.0
+)
+
+// AnomalyOptions configures the thresholds of anomaly detection. Zero values select the defaults.
+type AnomalyOptions struct {
+	// Window is the number of preceding ledger months a month is compared against
+	Window int
+	// MinHistory is the number of preceding months, or entries of a tag, needed before anything is flagged
+	MinHistory int
+	// Threshold is the deviation from the trailing median, in percent of the median,
+	// above which month expenses and tag spend are flagged
+	Threshold float64
+	// Factor is how many times larger than typical an entry or an account balance change must be to be flagged
+	Factor float64
+	// MinAmount is the smallest absolute deviation flagged, to ignore small amounts
+	MinAmount int
+}
+
+func (o AnomalyOptions) withDefaults() AnomalyOptions {
+	if o.Window <= 0 {
+		o.Window = DefaultAnomalyWindow
+	}
+	if o.MinHistory <= 0 {
+		o.MinHistory = DefaultAnomalyMinHistory
+	}
+	if o.Threshold <= 0 {
+		o.Threshold = DefaultAnomalyThreshold
+	}
+	if o.Factor <= 0 {
+		o.Factor = DefaultAnomalyFactor
+	}
+	return o
+}
+
+// Anomaly is a month, tag, entry or account balance change that deviates from its history.
+// Delta holds the typical value as Base and the actual value as Value.
+type Anomaly struct {
+	Kind AnomalyKind
+	YearMonth
+	Tag     string
+	Account string
+	// Note is the note of an entry anomaly
+	Note string
+	Delta
+}
+
+// Anomalies flags what deviates from the preceding months of the ledger:
+//   - months whose expenses deviate from the median of the trailing window by more than Threshold percent
+//   - months whose non-internal total of a tag deviates likewise
+//   - non-internal entries larger than Factor times the median size of the tag's entries in the window
+//   - account balance changes (closing minus opening) farther than Factor times the median size
+//     of the account's changes from their median
+//
+// Anomalies are sorted by month, kind, tag, account and note.
+func (l Ledger) Anomalies(opts AnomalyOptions) []Anomaly {
+	opts = opts.withDefaults()
+	months := l.GetMonths()
+
+	var result []Anomaly
+	for i, ym := range months {
+		window := months[max(i-opts.Window, 0):i]
+		if len(window) < opts.MinHistory {
+			continue
+		}
+		month := l.Years[ym.Year].Months[ym.Month]
+		history := lo.Map(window, func(w YearMonth, _ int) Month { return l.Years[w.Year].Months[w.Month] })
+
+		expenses := Delta{Base: median(lo.Map(history, func(m Month, _ int) int { return m.Expenses() })), Value: month.Expenses()}
+		if opts.deviates(expenses) {
+			result = append(result, Anomaly{Kind: AnomalyExpenses, YearMonth: ym, Delta: expenses})
+		}
+
+		result = append(result, l.tagAnomalies(ym, month, history, opts)...)
+		result = append(result, entryAnomalies(ym, month, history, opts)...)
+	}
+
+	result = append(result, l.accountAnomalies(opts)...)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.YearMonth != b.YearMonth {
+			return a.YearMonth.Before(b.YearMonth)
+		}
+		if a.Kind != b.Kind {
+			return anomalyKindOrder[a.Kind] < anomalyKindOrder[b.Kind]
+		}
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		return a.Note < b.Note
+	})
+
+	return result
+}
+
+// deviates reports whether a value deviates from its median by more than the threshold and the minimum amount
+func (o AnomalyOptions) deviates(d Delta) bool {
+	change := math.Abs(float64(d.Change()))
+	return change > math.Abs(float64(d.Base))*o.Threshold/100 && change >= float64(max(o.MinAmount, 1))
+}
+
+// exceeds reports whether a size is more than the factor times the typical size and differs by the minimum amount
+func (o AnomalyOptions) exceeds(size, typical int) bool {
+	return float64(size) > float64(typical)*o.Factor && size-typical >= max(o.MinAmount, 1)
+}
+
+// tagAnomalies flags the tags of a month whose total deviates from the median of the history months.
+// Tags absent from a month count as zero.
+func (l Ledger) tagAnomalies(ym YearMonth, month Month, history []Month, opts AnomalyOptions) []Anomaly {
+	totals := month.TagTotals()
+	historyTotals := lo.Map(history, func(m Month, _ int) map[string]int { return m.TagTotals() })
+
+	tags := lo.Keys(totals)
+	for _, t := range historyTotals {
+		tags = append(tags, lo.Keys(t)...)
+	}
+
+	var result []Anomaly
+	for _, tag := range lo.Uniq(tags) {
+		d := Delta{
+			Base:  median(lo.Map(historyTotals, func(t map[string]int, _ int) int { return t[tag] })),
+			Value: totals[tag],
+		}
+		if opts.deviates(d) {
+			result = append(result, Anomaly{Kind: AnomalyTag, YearMonth: ym, Tag: tag, Delta: d})
+		}
+	}
+	return result
+}
+
+// entryAnomalies flags the non-internal entries of a month larger than the factor times
+// the median size of the entries of their tag in the history months
+func entryAnomalies(ym YearMonth, month Month, history []Month, opts AnomalyOptions) []Anomaly {
+	sizes := make(map[string][]int)
+	for _, m := range history {
+		for _, account := range m.Accounts {
+			for _, entry := range account.Entries {
+				if !entry.Internal {
+					sizes[entry.Tag] = append(sizes[entry.Tag], Abs(entry.Amount))
+				}
+			}
+		}
+	}
+
+	var result []Anomaly
+	for _, accountName := range month.GetAccountNames() {
+		for _, entry := range month.Accounts[accountName].Entries {
+			if entry.Internal || len(sizes[entry.Tag]) < opts.MinHistory {
+				continue
+			}
+
+			typical := median(sizes[entry.Tag])
+			if !opts.exceeds(Abs(entry.Amount), typical) {
+				continue
+			}
+			if entry.Amount < 0 {
+				typical = -typical
+			}
+
+			result = append(result, Anomaly{
+				Kind:      AnomalyEntry,
+				YearMonth: ym,
+				Tag:       entry.Tag,
+				Account:   accountName,
+				Note:      entry.Note,
+				Delta:     Delta{Base: typical, Value: entry.Amount},
+			})
+		}
+	}
+	return result
+}
+
+// accountAnomalies flags account balance changes farther from the median change of the account's
+// preceding months than the factor times the median size of those changes
+func (l Ledger) accountAnomalies(opts AnomalyOptions) []Anomaly {
+	var result []Anomaly
+	for name, months := range l.AccountHistory() {
+		changes := lo.Map(months, func(m AccountMonth, _ int) int {
+			return m.Account.ClosingBalance - m.Account.OpeningBalance
+		})
+
+		for i, m := range months {
+			window := changes[max(i-opts.Window, 0):i]
+			if len(window) < opts.MinHistory {
+				continue
+			}
+
+			expected := median(window)
+			typical := median(lo.Map(window, func(c, _ int) int { return Abs(c - expected) }))
+			if !opts.exceeds(Abs(changes[i]-expected), typical) {
+				continue
+			}
+
+			result = append(result, Anomaly{
+				Kind:      AnomalyAccount,
+				YearMonth: YearMonth{Year: m.Year, Month: m.Month},
+				Account:   name,
+				Delta:     Delta{Base: expected, Value: changes[i]},
+			})
+		}
+	}
+	return result
+}
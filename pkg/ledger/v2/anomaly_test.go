@@ -0,0 +1,97 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger_Anomalies(t *testing.T) {
+	salary := Entry{Amount: 1000, Note: "Salary", Tag: "Income"}
+	food := func(amount int) Entry { return Entry{Amount: -amount, Note: "Groceries", Tag: "Food"} }
+
+	repair := Ledger{Years: map[int]Year{
+		2025: {Months: map[int]Month{
+			1: testMonth(0, salary, food(100), food(110)),
+			2: testMonth(890, salary, food(90), food(100)),
+			3: testMonth(1700, salary, food(100), food(105)),
+			// A usual month: nothing flagged
+			4: testMonth(2495, salary, food(95), food(110)),
+			// A car repair: the expenses, the new tag and the account swing are flagged. The entry itself
+			// is not, as there are no earlier entries of its tag to compare against.
+			5: testMonth(3290, salary, food(100), food(100), Entry{Amount: -2000, Note: "Car repair", Tag: "Car"}),
+		}},
+	}}
+
+	// restaurant returns a month of restaurant bills, the rent keeping the expenses and the balance stable
+	restaurant := func(amounts ...int) Month {
+		var entries []Entry
+		for _, a := range amounts {
+			entries = append(entries, Entry{Amount: a, Note: "Restaurant", Tag: "Eating out"})
+		}
+		return testMonth(0, append(entries, Entry{Amount: -1000 - lo.Sum(amounts), Note: "Rent", Tag: "Housing"})...)
+	}
+	bills := Ledger{Years: map[int]Year{
+		2025: {Months: map[int]Month{
+			1: restaurant(-20, -30),
+			2: restaurant(-25),
+			3: restaurant(-40, -20),
+			4: restaurant(-100, -30),
+		}},
+	}}
+
+	tests := []struct {
+		name   string
+		ledger Ledger
+		opts   AnomalyOptions
+		want   []Anomaly
+	}{
+		{
+			name:   "car repair",
+			ledger: repair,
+			want: []Anomaly{
+				{Kind: AnomalyExpenses, YearMonth: YearMonth{2025, 5}, Delta: Delta{Base: -205, Value: -2200}},
+				{Kind: AnomalyTag, YearMonth: YearMonth{2025, 5}, Tag: "Car", Delta: Delta{Base: 0, Value: -2000}},
+				{Kind: AnomalyAccount, YearMonth: YearMonth{2025, 5}, Account: "Checking", Delta: Delta{Base: 795, Value: -1200}},
+			},
+		},
+		{
+			name:   "not enough history",
+			ledger: repair,
+			opts:   AnomalyOptions{MinHistory: 5},
+		},
+		{
+			name:   "below the minimum amount",
+			ledger: repair,
+			opts:   AnomalyOptions{MinAmount: 5000},
+		},
+		{
+			// The median restaurant bill is 25, and 100 is more than three times that
+			name:   "large entry",
+			ledger: bills,
+			opts:   AnomalyOptions{MinHistory: 3, Factor: 3},
+			want: []Anomaly{
+				{Kind: AnomalyTag, YearMonth: YearMonth{2025, 4}, Tag: "Eating out", Delta: Delta{Base: -50, Value: -130}},
+				{
+					Kind:      AnomalyEntry,
+					YearMonth: YearMonth{2025, 4},
+					Tag:       "Eating out",
+					Account:   "Checking",
+					Note:      "Restaurant",
+					Delta:     Delta{Base: -25, Value: -100},
+				},
+			},
+		},
+		{
+			name:   "empty ledger",
+			ledger: Ledger{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ledger.Anomalies(tt.opts))
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package v2
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// BudgetPeriod is the span of time a budget amount covers
+type BudgetPeriod string
+
+const (
+	BudgetMonthly BudgetPeriod = "month"
+	BudgetYearly  BudgetPeriod = "year"
+)
+
+// Budget plans the spending of a tag per month or per year.
+// A budget applies from its From month onward, until a later budget of the same tag and period replaces it;
+// without From it applies from the start of the ledger.
+type Budget struct {
+	Tag string `json:"tag" yaml:"tag" toml:"tag"`
+	// Amount is the planned spending as a positive number, in the same units as entry amounts
+	Amount int `json:"amount" yaml:"amount" toml:"amount"`
+	// Period is either "month" or "year", defaulting to "month"
+	Period BudgetPeriod `json:"period,omitempty" yaml:"period,omitempty" toml:"period,omitempty"`
+	// From is the first month the budget applies, in YYYY-MM format. Yearly budgets must start in January.
+	From string `json:"from,omitempty" yaml:"from,omitempty" toml:"from,omitempty"`
+}
+
+// GetPeriod returns the budget period, defaulting to monthly
+func (b Budget) GetPeriod() BudgetPeriod {
+	if b.Period == "" {
+		return BudgetMonthly
+	}
+	return b.Period
+}
+
+// start returns the first month the budget applies, or the zero YearMonth if it applies from the start
+func (b Budget) start() YearMonth {
+	ym, _ := ParseYearMonth(b.From)
+	return ym
+}
+
+// ValidateAll validates a budget according to the B-* rules and returns every violated rule
+func (b Budget) ValidateAll() []Violation {
+	var violations []Violation
+
+	if b.Tag == "" || b.Amount <= 0 {
+		violations = append(violations, newViolation("B-1", 0, b.Amount,
+			"budget must have a non-empty tag and a positive amount, got tag %q and amount %d", b.Tag, b.Amount))
+	}
+
+	period := b.GetPeriod()
+	if period != BudgetMonthly && period != BudgetYearly {
+		violations = append(violations, newViolation("B-2", 0, 0,
+			"budget period must be %q or %q, got %q", BudgetMonthly, BudgetYearly, b.Period))
+	}
+
+	if b.From != "" {
+		ym, err := ParseYearMonth(b.From)
+		if err != nil {
+			violations = append(violations, newViolation("B-3", 0, 0, "budget from %q must be in YYYY-MM format", b.From))
+		} else if period == BudgetYearly && ym.Month != 1 {
+			violations = append(violations, newViolation("B-3", 1, ym.Month, "yearly budget must start in January, got %s", b.From))
+		}
+	}
+
+	return violations
+}
+
+// validateBudgets validates every budget and checks that no two budgets of a tag and period start together
+func (l Ledger) validateBudgets() []Violation {
+	var violations []Violation
+	starts := make(map[string]int)
+
+	for i, budget := range l.Budgets {
+		invalidStart := false
+		for _, violation := range budget.ValidateAll() {
+			violation.Path.Budget = lo.ToPtr(i)
+			violations = append(violations, violation)
+			invalidStart = invalidStart || violation.Rule == "B-3"
+		}
+
+		// A start failing B-3 has no month to overlap with
+		if invalidStart {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", budget.Tag, budget.GetPeriod(), budget.start())
+		if prev, ok := starts[key]; ok {
+			violation := newViolation("B-4", 0, 0,
+				"budget duplicates budget %d: tag %q already has a %s budget starting %s",
+				prev, budget.Tag, budget.GetPeriod(), budgetStart(budget))
+			violation.Path.Budget = lo.ToPtr(i)
+			violations = append(violations, violation)
+			continue
+		}
+		starts[key] = i
+	}
+
+	return violations
+}
+
+// budgetStart describes when a budget starts, for messages
+func budgetStart(b Budget) string {
+	if b.From == "" {
+		return "from the start"
+	}
+	return "in " + b.From
+}
+
+// BudgetAmount returns the amount budgeted for the tag over the period containing the month:
+// the amount of the latest budget of that tag and period starting on or before it.
+// Yearly budgets are looked up at January of the month's year.
+func (l Ledger) BudgetAmount(tag string, period BudgetPeriod, ym YearMonth) (int, bool) {
+	if period == BudgetYearly {
+		ym = YearMonth{Year: ym.Year, Month: 1}
+	}
+
+	var found *Budget
+	for i, budget := range l.Budgets {
+		if budget.Tag != tag || budget.GetPeriod() != period || ym.Before(budget.start()) {
+			continue
+		}
+		if found == nil || found.start().Before(budget.start()) {
+			found = &l.Budgets[i]
+		}
+	}
+
+	if found == nil {
+		return 0, false
+	}
+	return found.Amount, true
+}
+
+// BudgetLine compares the spending of a tag against its budget
+type BudgetLine struct {
+	Budget int
+	// Spent is the negated total of the tag's non-internal entries, so refunds reduce it
+	Spent int
+}
+
+// Remaining returns the budget left, negative when overspent
+func (b BudgetLine) Remaining() int {
+	return b.Budget - b.Spent
+}
+
+// Used returns the share of the budget spent in percent, or nil without a budget
+func (b BudgetLine) Used() *float64 {
+	if b.Budget == 0 {
+		return nil
+	}
+	used := float64(b.Spent) / float64(b.Budget) * 100
+	return &used
+}
+
+// Overspent reports whether more than the budget was spent
+func (b BudgetLine) Overspent() bool {
+	return b.Remaining() < 0
+}
+
+// TagBudget is the budget of a tag for a month and for its year to date
+type TagBudget struct {
+	Tag        string
+	Month      BudgetLine
+	YearToDate BudgetLine
+}
+
+// BudgetReport compares spending against the budgets in effect in a month
+type BudgetReport struct {
+	YearMonth
+	// Monthly holds the tags with a monthly budget in effect in the month. Their year to date
+	// totals cover the months of the year up to this one that are present in the ledger and budgeted.
+	Monthly []TagBudget
+	// Yearly holds the tags with a yearly budget for the month's year, with the spending
+	// of the months of the year up to this one
+	Yearly []TagBudget
+}
+
+// BudgetReport compares the spending of the month and of its year to date against the budgets, by tag
+func (l Ledger) BudgetReport(ym YearMonth) BudgetReport {
+	result := BudgetReport{YearMonth: ym}
+
+	var months []YearMonth
+	for _, m := range l.GetMonths() {
+		if m.Year == ym.Year && !ym.Before(m) {
+			months = append(months, m)
+		}
+	}
+	spent := func(m YearMonth, tag string) int {
+		return -l.Years[m.Year].Months[m.Month].TagTotals()[tag]
+	}
+
+	for _, tag := range l.budgetTags() {
+		if amount, ok := l.BudgetAmount(tag, BudgetMonthly, ym); ok {
+			line := TagBudget{Tag: tag, Month: BudgetLine{Budget: amount, Spent: spent(ym, tag)}}
+			for _, m := range months {
+				if monthAmount, ok := l.BudgetAmount(tag, BudgetMonthly, m); ok {
+					line.YearToDate.Budget += monthAmount
+					line.YearToDate.Spent += spent(m, tag)
+				}
+			}
+			result.Monthly = append(result.Monthly, line)
+		}
+
+		if amount, ok := l.BudgetAmount(tag, BudgetYearly, ym); ok {
+			line := TagBudget{Tag: tag, YearToDate: BudgetLine{Budget: amount}}
+			line.Month.Spent = spent(ym, tag)
+			for _, m := range months {
+				line.YearToDate.Spent += spent(m, tag)
+			}
+			result.Yearly = append(result.Yearly, line)
+		}
+	}
+
+	return result
+}
+
+// budgetTags returns the sorted tags having any budget
+func (l Ledger) budgetTags() []string {
+	tags := lo.Uniq(lo.Map(l.Budgets, func(b Budget, _ int) string { return b.Tag }))
+	sort.Strings(tags)
+	return tags
+}
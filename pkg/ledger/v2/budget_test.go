@@ -0,0 +1,182 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget_ValidateAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget Budget
+		rules  []string
+	}{
+		{name: "monthly by default", budget: Budget{Tag: "Food", Amount: 400}},
+		{name: "yearly from january", budget: Budget{Tag: "Travel", Amount: 2000, Period: BudgetYearly, From: "2025-01"}},
+		{name: "monthly from any month", budget: Budget{Tag: "Food", Amount: 400, From: "2025-07"}},
+		{name: "missing tag", budget: Budget{Amount: 400}, rules: []string{"B-1"}},
+		{name: "zero amount", budget: Budget{Tag: "Food"}, rules: []string{"B-1"}},
+		{name: "negative amount", budget: Budget{Tag: "Food", Amount: -400}, rules: []string{"B-1"}},
+		{name: "unknown period", budget: Budget{Tag: "Food", Amount: 400, Period: "week"}, rules: []string{"B-2"}},
+		{name: "invalid from", budget: Budget{Tag: "Food", Amount: 400, From: "2025-1-1"}, rules: []string{"B-3"}},
+		{name: "yearly from july", budget: Budget{Tag: "Food", Amount: 400, Period: BudgetYearly, From: "2025-07"}, rules: []string{"B-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules []string
+			for _, v := range tt.budget.ValidateAll() {
+				rules = append(rules, v.Rule)
+			}
+			assert.Equal(t, tt.rules, rules)
+		})
+	}
+}
+
+func TestLedger_ValidateBudgets(t *testing.T) {
+	ledger := Ledger{Budgets: []Budget{
+		{Tag: "Food", Amount: 400},
+		{Tag: "Food", Amount: 500, From: "2025-01"},
+		{Tag: "Food", Amount: 5000, Period: BudgetYearly},
+		{Tag: "Food", Amount: 450, Period: BudgetMonthly},
+		{Tag: "", Amount: 100},
+	}}
+
+	violations := ledger.ValidateAll()
+	require.Len(t, violations, 2)
+
+	assert.Equal(t, "B-4", violations[0].Rule)
+	assert.Equal(t, Path{Budget: lo.ToPtr(3)}, violations[0].Path)
+	assert.Equal(t, `budget 3: B-4: budget duplicates budget 0: tag "Food" already has a month budget starting from the start`,
+		violations[0].Error())
+
+	assert.Equal(t, "B-1", violations[1].Rule)
+	assert.Equal(t, Path{Budget: lo.ToPtr(4)}, violations[1].Path)
+
+	// A start that does not parse is reported by B-3 only, not as overlapping a budget without start
+	ledger = Ledger{Budgets: []Budget{
+		{Tag: "Food", Amount: 400},
+		{Tag: "Food", Amount: 500, From: "2025-1"},
+	}}
+	violations = ledger.ValidateAll()
+	require.Len(t, violations, 1)
+	assert.Equal(t, "B-3", violations[0].Rule)
+	assert.Equal(t, Path{Budget: lo.ToPtr(1)}, violations[0].Path)
+}
+
+func TestLedger_BudgetAmount(t *testing.T) {
+	ledger := Ledger{Budgets: []Budget{
+		{Tag: "Food", Amount: 500, From: "2025-04"},
+		{Tag: "Food", Amount: 400},
+		{Tag: "Food", Amount: 450, From: "2025-01"},
+		{Tag: "Travel", Amount: 2000, Period: BudgetYearly, From: "2025-01"},
+	}}
+
+	tests := []struct {
+		name   string
+		tag    string
+		period BudgetPeriod
+		ym     YearMonth
+		want   int
+		found  bool
+	}{
+		{name: "default before any start", tag: "Food", period: BudgetMonthly, ym: YearMonth{2024, 6}, want: 400, found: true},
+		{name: "rolls forward", tag: "Food", period: BudgetMonthly, ym: YearMonth{2025, 3}, want: 450, found: true},
+		{name: "replaced by a later budget", tag: "Food", period: BudgetMonthly, ym: YearMonth{2025, 4}, want: 500, found: true},
+		{name: "rolls into later years", tag: "Food", period: BudgetMonthly, ym: YearMonth{2030, 1}, want: 500, found: true},
+		{name: "yearly in any month of the year", tag: "Travel", period: BudgetYearly, ym: YearMonth{2025, 9}, want: 2000, found: true},
+		{name: "yearly before its start", tag: "Travel", period: BudgetYearly, ym: YearMonth{2024, 12}},
+		{name: "no monthly travel budget", tag: "Travel", period: BudgetMonthly, ym: YearMonth{2025, 9}},
+		{name: "unknown tag", tag: "Rent", period: BudgetMonthly, ym: YearMonth{2025, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, found := ledger.BudgetAmount(tt.tag, tt.period, tt.ym)
+			assert.Equal(t, tt.want, amount)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
+
+func TestBudgetLine(t *testing.T) {
+	line := BudgetLine{Budget: 400, Spent: 500}
+	assert.Equal(t, -100, line.Remaining())
+	assert.Equal(t, lo.ToPtr(125.0), line.Used())
+	assert.True(t, line.Overspent())
+
+	assert.False(t, BudgetLine{Budget: 400, Spent: 400}.Overspent())
+	assert.Nil(t, BudgetLine{Spent: 10}.Used())
+}
+
+func TestLedger_BudgetReport(t *testing.T) {
+	ledger := Ledger{
+		Budgets: []Budget{
+			{Tag: "Food", Amount: 300},
+			{Tag: "Food", Amount: 400, From: "2025-03"},
+			{Tag: "Travel", Amount: 1000, Period: BudgetYearly},
+		},
+		Years: map[int]Year{
+			2024: {Months: map[int]Month{
+				12: testMonth(0, Entry{Amount: -999, Note: "Party", Tag: "Food"}),
+			}},
+			2025: {Months: map[int]Month{
+				1: testMonth(0, Entry{Amount: -250, Note: "Groceries", Tag: "Food"}, Entry{Amount: -600, Note: "Flights", Tag: "Travel"}),
+				2: testMonth(0, Entry{Amount: -350, Note: "Groceries", Tag: "Food"}, Entry{Amount: 20, Note: "Refund", Tag: "Food"}),
+				3: testMonth(0, Entry{Amount: -450, Note: "Groceries", Tag: "Food"}, Entry{Amount: -500, Note: "Hotel", Tag: "Travel"}),
+				4: testMonth(0, Entry{Amount: -100, Note: "Groceries", Tag: "Food"}),
+			}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		ledger Ledger
+		ym     YearMonth
+		want   BudgetReport
+	}{
+		{
+			name:   "first month of the year",
+			ledger: ledger,
+			ym:     YearMonth{2025, 1},
+			want: BudgetReport{
+				YearMonth: YearMonth{2025, 1},
+				Monthly:   []TagBudget{{Tag: "Food", Month: BudgetLine{Budget: 300, Spent: 250}, YearToDate: BudgetLine{Budget: 300, Spent: 250}}},
+				Yearly:    []TagBudget{{Tag: "Travel", Month: BudgetLine{Spent: 600}, YearToDate: BudgetLine{Budget: 1000, Spent: 600}}},
+			},
+		},
+		{
+			name:   "budget replaced during the year",
+			ledger: ledger,
+			ym:     YearMonth{2025, 3},
+			want: BudgetReport{
+				YearMonth: YearMonth{2025, 3},
+				Monthly: []TagBudget{{
+					Tag:        "Food",
+					Month:      BudgetLine{Budget: 400, Spent: 450},
+					YearToDate: BudgetLine{Budget: 300 + 300 + 400, Spent: 250 + 330 + 450},
+				}},
+				Yearly: []TagBudget{{
+					Tag:        "Travel",
+					Month:      BudgetLine{Spent: 500},
+					YearToDate: BudgetLine{Budget: 1000, Spent: 1100},
+				}},
+			},
+		},
+		{
+			name:   "empty ledger",
+			ledger: Ledger{},
+			ym:     YearMonth{2025, 1},
+			want:   BudgetReport{YearMonth: YearMonth{2025, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ledger.BudgetReport(tt.ym))
+		})
+	}
+}
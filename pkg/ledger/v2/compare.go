@@ -0,0 +1,100 @@
+package v2
+
+// Delta is a value compared against a base value, e.g. this year's expenses against last year's
+type Delta struct {
+	Base  int
+	Value int
+}
+
+// Change returns the absolute difference from the base value
+func (d Delta) Change() int {
+	return d.Value - d.Base
+}
+
+// Percent returns the difference relative to the magnitude of the base value in percent,
+// or nil if the base is zero
+func (d Delta) Percent() *float64 {
+	if d.Base == 0 {
+		return nil
+	}
+	base := d.Base
+	if base < 0 {
+		base = -base
+	}
+	percent := float64(d.Change()) / float64(base) * 100
+	return &percent
+}
+
+// MonthComparison compares the same month of two years
+type MonthComparison struct {
+	Month int
+	// InBase and InYear report whether the month is present in the base year and the compared year
+	InBase   bool
+	InYear   bool
+	Income   Delta
+	Expenses Delta
+}
+
+// Matching reports whether the month is present in both years
+func (m MonthComparison) Matching() bool {
+	return m.InBase && m.InYear
+}
+
+// YearComparison compares a year against a base year, month by month
+type YearComparison struct {
+	BaseYear int
+	Year     int
+	// Months holds every month present in either year, in order
+	Months []MonthComparison
+	// Income, Expenses and Tags are totaled over the months present in both years only,
+	// so a month missing from one year does not distort the comparison
+	Income   Delta
+	Expenses Delta
+	Tags     map[string]Delta
+}
+
+// CompareYears compares the months of a year against the same months of a base year.
+// Missing years are treated as years without months.
+func (l Ledger) CompareYears(baseYear, year int) YearComparison {
+	base, current := l.Years[baseYear], l.Years[year]
+	result := YearComparison{BaseYear: baseYear, Year: year, Tags: make(map[string]Delta)}
+
+	for monthNum := 1; monthNum <= 12; monthNum++ {
+		baseMonth, inBase := base.Months[monthNum]
+		month, inYear := current.Months[monthNum]
+		if !inBase && !inYear {
+			continue
+		}
+
+		cmp := MonthComparison{
+			Month:    monthNum,
+			InBase:   inBase,
+			InYear:   inYear,
+			Income:   Delta{Base: baseMonth.Income(), Value: month.Income()},
+			Expenses: Delta{Base: baseMonth.Expenses(), Value: month.Expenses()},
+		}
+		result.Months = append(result.Months, cmp)
+
+		if !cmp.Matching() {
+			continue
+		}
+
+		result.Income.Base += cmp.Income.Base
+		result.Income.Value += cmp.Income.Value
+		result.Expenses.Base += cmp.Expenses.Base
+		result.Expenses.Value += cmp.Expenses.Value
+
+		for tag, amount := range baseMonth.TagTotals() {
+			delta := result.Tags[tag]
+			delta.Base += amount
+			result.Tags[tag] = delta
+		}
+		for tag, amount := range month.TagTotals() {
+			delta := result.Tags[tag]
+			delta.Value += amount
+			result.Tags[tag] = delta
+		}
+	}
+
+	return result
+}
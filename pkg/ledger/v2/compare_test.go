@@ -0,0 +1,110 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelta(t *testing.T) {
+	tests := []struct {
+		name    string
+		delta   Delta
+		change  int
+		percent *float64
+	}{
+		{name: "increase", delta: Delta{Base: 200, Value: 250}, change: 50, percent: lo.ToPtr(25.0)},
+		{name: "decrease", delta: Delta{Base: 200, Value: 150}, change: -50, percent: lo.ToPtr(-25.0)},
+		{name: "more expenses", delta: Delta{Base: -200, Value: -300}, change: -100, percent: lo.ToPtr(-50.0)},
+		{name: "fewer expenses", delta: Delta{Base: -200, Value: -100}, change: 100, percent: lo.ToPtr(50.0)},
+		{name: "zero base", delta: Delta{Base: 0, Value: 100}, change: 100, percent: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.change, tt.delta.Change())
+			assert.Equal(t, tt.percent, tt.delta.Percent())
+		})
+	}
+}
+
+func TestMonthComparison_Matching(t *testing.T) {
+	assert.True(t, MonthComparison{InBase: true, InYear: true}.Matching())
+	assert.False(t, MonthComparison{InBase: true}.Matching())
+	assert.False(t, MonthComparison{InYear: true}.Matching())
+}
+
+func TestLedger_CompareYears(t *testing.T) {
+	ledger := Ledger{Years: map[int]Year{
+		2024: {Months: map[int]Month{
+			1: testMonth(0, Entry{Amount: 100, Note: "Salary", Tag: "Income"}, Entry{Amount: -40, Note: "Food", Tag: "Food"}),
+			2: testMonth(0, Entry{Amount: 100, Note: "Salary", Tag: "Income"}),
+		}},
+		2025: {Months: map[int]Month{
+			1: testMonth(0, Entry{Amount: 120, Note: "Salary", Tag: "Income"}, Entry{Amount: -60, Note: "Food", Tag: "Food"},
+				Entry{Amount: -10, Note: "Cinema"}),
+			3: testMonth(0, Entry{Amount: 500, Note: "Bonus", Tag: "Income"}),
+		}},
+	}}
+
+	tests := []struct {
+		name     string
+		ledger   Ledger
+		baseYear int
+		year     int
+		want     YearComparison
+	}{
+		{
+			// Totals only cover January, the only month present in both years
+			name:     "partially matching years",
+			ledger:   ledger,
+			baseYear: 2024,
+			year:     2025,
+			want: YearComparison{
+				BaseYear: 2024,
+				Year:     2025,
+				Months: []MonthComparison{
+					{Month: 1, InBase: true, InYear: true, Income: Delta{100, 120}, Expenses: Delta{-40, -70}},
+					{Month: 2, InBase: true, InYear: false, Income: Delta{100, 0}, Expenses: Delta{0, 0}},
+					{Month: 3, InBase: false, InYear: true, Income: Delta{0, 500}, Expenses: Delta{0, 0}},
+				},
+				Income:   Delta{100, 120},
+				Expenses: Delta{-40, -70},
+				Tags: map[string]Delta{
+					"Income": {100, 120},
+					"Food":   {-40, -60},
+					"":       {0, -10},
+				},
+			},
+		},
+		{
+			name:     "missing base year",
+			ledger:   ledger,
+			baseYear: 2020,
+			year:     2025,
+			want: YearComparison{
+				BaseYear: 2020,
+				Year:     2025,
+				Months: []MonthComparison{
+					{Month: 1, InYear: true, Income: Delta{0, 120}, Expenses: Delta{0, -70}},
+					{Month: 3, InYear: true, Income: Delta{0, 500}, Expenses: Delta{0, 0}},
+				},
+				Tags: map[string]Delta{},
+			},
+		},
+		{
+			name:     "empty ledger",
+			ledger:   Ledger{},
+			baseYear: 2024,
+			year:     2025,
+			want:     YearComparison{BaseYear: 2024, Year: 2025, Tags: map[string]Delta{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ledger.CompareYears(tt.baseYear, tt.year))
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultScale is the number of stored units per currency unit when a ledger does not set one
+	DefaultScale = 1000
+	// DefaultDecimals is the number of decimal places displayed when a ledger does not set one
+	DefaultDecimals = 2
+	// maxScale and maxDecimals keep the fixed-point arithmetic of Format and ParseAmount within 64 bits:
+	// a remainder below the scale times 10^decimals times 2 stays below 2*10^18
+	maxScale    = 1_000_000_000
+	maxDecimals = 9
+)
+
+// Currency describes how the integer amounts of a ledger are displayed.
+// Amounts are always stored as integers; Scale only affects reports.
+type Currency struct {
+	// Scale is the number of stored units per currency unit, e.g. 1 for whole dollars or 100 for cents
+	Scale int `json:"scale,omitempty" yaml:"scale,omitempty" toml:"scale,omitempty"`
+	// Symbol is prepended to formatted amounts, e.g. "$"
+	Symbol string `json:"symbol,omitempty" yaml:"symbol,omitempty" toml:"symbol,omitempty"`
+	// Decimals is the number of decimal places displayed
+	Decimals *int `json:"decimals,omitempty" yaml:"decimals,omitempty" toml:"decimals,omitempty"`
+	// ThousandsSeparator groups the digits of the integer part, e.g. "," or " "; empty disables grouping
+	ThousandsSeparator string `json:"thousands_separator,omitempty" yaml:"thousands_separator,omitempty" toml:"thousands_separator,omitempty"`
+	// DecimalSeparator separates the fractional part, "." by default
+	DecimalSeparator string `json:"decimal_separator,omitempty" yaml:"decimal_separator,omitempty" toml:"decimal_separator,omitempty"`
+}
+
+// Validate checks that the currency settings can be used to format amounts
+func (c Currency) Validate() error {
+	if c.Scale < 0 {
+		return errors.New("scale must be positive")
+	}
+	if c.Scale > maxScale {
+		return errors.New("scale must be at most 1000000000")
+	}
+	if c.Decimals != nil && (*c.Decimals < 0 || *c.Decimals > maxDecimals) {
+		return errors.New("decimals must be between 0 and 9")
+	}
+	return nil
+}
+
+// GetScale returns the scale, or DefaultScale if not set
+func (c Currency) GetScale() int {
+	if c.Scale <= 0 {
+		return DefaultScale
+	}
+	return min(c.Scale, maxScale)
+}
+
+// GetDecimals returns the number of decimal places, or DefaultDecimals if not set
+func (c Currency) GetDecimals() int {
+	if c.Decimals == nil {
+		return DefaultDecimals
+	}
+	return min(max(*c.Decimals, 0), maxDecimals)
+}
+
+// Format formats an amount with the currency symbol and separators, e.g. "-$1,234.50"
+func (c Currency) Format(amount int) string {
+	number := c.format(amount, c.ThousandsSeparator)
+	if c.Symbol == "" {
+		return number
+	}
+	if strings.HasPrefix(number, "-") {
+		return "-" + c.Symbol + number[1:]
+	}
+	return c.Symbol + number
+}
+
+// FormatNumber formats an amount as a plain number without symbol and thousands separators,
+// suitable for further processing, e.g. "-1234.50"
+func (c Currency) FormatNumber(amount int) string {
+	return c.format(amount, "")
+}
+
+// ParseAmount parses a number in currency units, e.g. "-1234.50", into stored units,
+// rounding half away from zero. Both "." and the decimal separator are accepted.
+func (c Currency) ParseAmount(s string) (int, error) {
+	number := strings.TrimSpace(s)
+	if c.DecimalSeparator != "" {
+		number = strings.Replace(number, c.DecimalSeparator, ".", 1)
+	}
+
+	negative := strings.HasPrefix(number, "-")
+	number = strings.TrimPrefix(strings.TrimPrefix(number, "-"), "+")
+
+	wholeDigits, fractionDigits, _ := strings.Cut(number, ".")
+	if wholeDigits == "" && fractionDigits == "" || len(fractionDigits) > maxDecimals {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	for _, digits := range []string{wholeDigits, fractionDigits} {
+		if strings.Trim(digits, "0123456789") != "" {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+
+	whole, fraction, pow := int64(0), int64(0), int64(1)
+	var err error
+	if wholeDigits != "" {
+		if whole, err = strconv.ParseInt(wholeDigits, 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+		}
+	}
+	if fractionDigits != "" {
+		if fraction, err = strconv.ParseInt(fractionDigits, 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+		}
+		for range fractionDigits {
+			pow *= 10
+		}
+	}
+
+	scale := int64(c.GetScale())
+	if whole > math.MaxInt64/scale {
+		return 0, fmt.Errorf("amount %q out of range", s)
+	}
+	amount := whole * scale
+	rounded := (fraction*scale*2 + pow) / (pow * 2)
+	if amount > math.MaxInt64-rounded {
+		return 0, fmt.Errorf("amount %q out of range", s)
+	}
+	amount += rounded
+	if negative {
+		amount = -amount
+	}
+	return int(amount), nil
+}
+
+// format scales an amount using integer arithmetic, rounding half away from zero
+func (c Currency) format(amount int, thousands string) string {
+	scale := uint64(c.GetScale())
+	decimals := c.GetDecimals()
+	pow := uint64(1)
+	for i := 0; i < decimals; i++ {
+		pow *= 10
+	}
+
+	// Unsigned, so the magnitude of the smallest int does not overflow
+	negative := amount < 0
+	abs := uint64(amount)
+	if negative {
+		abs = -abs
+	}
+
+	// Split before multiplying to avoid overflowing on large amounts
+	whole, rest := abs/scale, abs%scale
+	fraction := (rest*pow*2 + scale) / (scale * 2)
+	if fraction >= pow {
+		whole++
+		fraction -= pow
+	}
+
+	var sb strings.Builder
+	if negative && (whole != 0 || fraction != 0) {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(groupDigits(strconv.FormatUint(whole, 10), thousands))
+	if decimals > 0 {
+		separator := c.DecimalSeparator
+		if separator == "" {
+			separator = "."
+		}
+		sb.WriteString(separator)
+		digits := strconv.FormatUint(fraction, 10)
+		sb.WriteString(strings.Repeat("0", decimals-len(digits)))
+		sb.WriteString(digits)
+	}
+
+	return sb.String()
+}
+
+// groupDigits inserts the separator between groups of three digits
+func groupDigits(digits, separator string) string {
+	if separator == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	var sb strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		sb.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteString(separator)
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+
+	return sb.String()
+}
+
+// GetCurrency returns the display settings of the ledger, or the defaults if none are set
+func (l Ledger) GetCurrency() Currency {
+	if l.Currency == nil {
+		return Currency{}
+	}
+	return *l.Currency
+}
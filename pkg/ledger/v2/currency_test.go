@@ -0,0 +1,161 @@
+package v2
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrency_Format(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency Currency
+		amount   int
+		expected string
+	}{
+		{name: "defaults", currency: Currency{}, amount: 1234567, expected: "1234.57"},
+		{name: "defaults negative", currency: Currency{}, amount: -150, expected: "-0.15"},
+		{name: "whole units", currency: Currency{Scale: 1}, amount: 50, expected: "50.00"},
+		{name: "whole units without decimals", currency: Currency{Scale: 1, Decimals: lo.ToPtr(0)}, amount: 50, expected: "50"},
+		{name: "cents", currency: Currency{Scale: 100, Symbol: "$"}, amount: 123456, expected: "$1234.56"},
+		{name: "negative with symbol", currency: Currency{Scale: 100, Symbol: "$"}, amount: -5, expected: "-$0.05"},
+		{
+			name:     "thousands separator",
+			currency: Currency{Scale: 1, Symbol: "$", ThousandsSeparator: ","},
+			amount:   -1234567,
+			expected: "-$1,234,567.00",
+		},
+		{
+			name:     "european style",
+			currency: Currency{Scale: 100, Symbol: "€", ThousandsSeparator: ".", DecimalSeparator: ","},
+			amount:   123456789,
+			expected: "€1.234.567,89",
+		},
+		{name: "rounds half away from zero", currency: Currency{Scale: 1000}, amount: 1005, expected: "1.01"},
+		{name: "rounds negative half away from zero", currency: Currency{Scale: 1000}, amount: -1005, expected: "-1.01"},
+		{name: "rounding carries into whole part", currency: Currency{Scale: 1000}, amount: 1999, expected: "2.00"},
+		{name: "negative rounding to zero has no sign", currency: Currency{Scale: 1000}, amount: -4, expected: "0.00"},
+		{name: "more decimals than scale", currency: Currency{Scale: 10, Decimals: lo.ToPtr(3)}, amount: 15, expected: "1.500"},
+		{name: "zero", currency: Currency{Scale: 1, ThousandsSeparator: ","}, amount: 0, expected: "0.00"},
+		{name: "exactly three digits", currency: Currency{Scale: 1, ThousandsSeparator: ","}, amount: 999, expected: "999.00"},
+		{
+			name:     "largest scale and amount",
+			currency: Currency{Scale: 1_000_000_000, Decimals: lo.ToPtr(9)},
+			amount:   math.MaxInt64,
+			expected: "9223372036.854775807",
+		},
+		{
+			name:     "smallest amount",
+			currency: Currency{Scale: 1_000_000_000, Decimals: lo.ToPtr(9)},
+			amount:   math.MinInt64,
+			expected: "-9223372036.854775808",
+		},
+		{name: "scale beyond the maximum", currency: Currency{Scale: math.MaxInt64}, amount: math.MaxInt64 - 1, expected: "9223372036.85"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.currency.Format(tt.amount))
+		})
+	}
+}
+
+func TestCurrency_FormatNumber(t *testing.T) {
+	currency := Currency{Scale: 1, Symbol: "$", ThousandsSeparator: ","}
+	assert.Equal(t, "-1234567.00", currency.FormatNumber(-1234567))
+}
+
+func TestCurrency_ParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency Currency
+		input    string
+		want     int
+		wantErr  bool
+	}{
+		{name: "whole units", currency: Currency{Scale: 1}, input: "1200", want: 1200},
+		{name: "default scale", currency: Currency{}, input: "-12.5", want: -12500},
+		{name: "cents", currency: Currency{Scale: 100}, input: "19.99", want: 1999},
+		{name: "rounds half away from zero", currency: Currency{Scale: 1}, input: "-2.5", want: -3},
+		{name: "decimal separator", currency: Currency{Scale: 100, DecimalSeparator: ","}, input: "3,25", want: 325},
+		{name: "fraction only", currency: Currency{Scale: 100}, input: ".5", want: 50},
+		{name: "explicit plus", currency: Currency{Scale: 1}, input: "+40", want: 40},
+		{name: "largest amount", currency: Currency{Scale: 1_000_000_000}, input: "9223372036.854775807", want: math.MaxInt64},
+		{name: "out of range", currency: Currency{Scale: 1_000_000_000}, input: "9223372037", wantErr: true},
+		{name: "rounded out of range", currency: Currency{Scale: 1_000_000_000}, input: "9223372036.9", wantErr: true},
+		{name: "empty", currency: Currency{}, input: "", wantErr: true},
+		{name: "letters", currency: Currency{}, input: "12a", wantErr: true},
+		{name: "symbol", currency: Currency{}, input: "$12", wantErr: true},
+		{name: "two points", currency: Currency{}, input: "1.2.3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.currency.ParseAmount(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCurrency_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency Currency
+		wantErr  string
+	}{
+		{name: "empty", currency: Currency{}},
+		{name: "valid", currency: Currency{Scale: 100, Decimals: lo.ToPtr(0)}},
+		{name: "negative scale", currency: Currency{Scale: -1}, wantErr: "scale must be positive"},
+		{name: "largest scale", currency: Currency{Scale: 1_000_000_000}},
+		{name: "scale too large", currency: Currency{Scale: 1_000_000_001}, wantErr: "scale must be at most 1000000000"},
+		{name: "negative decimals", currency: Currency{Decimals: lo.ToPtr(-1)}, wantErr: "decimals must be between 0 and 9"},
+		{name: "too many decimals", currency: Currency{Decimals: lo.ToPtr(10)}, wantErr: "decimals must be between 0 and 9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.currency.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLedger_Currency_RoundTrip(t *testing.T) {
+	ledger := Ledger{
+		Currency: &Currency{Scale: 100, Symbol: "$", Decimals: lo.ToPtr(0), ThousandsSeparator: ","},
+		Years: map[int]Year{
+			2025: {OpeningBalance: 100, ClosingBalance: 100, Months: map[int]Month{
+				1: {OpeningBalance: 100, ClosingBalance: 100, Accounts: map[string]Account{
+					"Checking": {OpeningBalance: 100, ClosingBalance: 100},
+				}},
+			}},
+		},
+	}
+
+	for _, ext := range []string{".yaml", ".json", ".toml"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ledger"+ext)
+			require.NoError(t, WriteLedger(ledger, path))
+
+			read, err := ReadLedger(path)
+			require.NoError(t, err)
+			assert.Equal(t, ledger.Currency, read.Currency)
+			assert.Equal(t, "$1", read.GetCurrency().Format(100))
+		})
+	}
+
+	assert.Equal(t, Currency{}, Ledger{}.GetCurrency())
+}
@@ -1,7 +1,7 @@
 package v2
 
 import (
-	"fmt"
+	"strings"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
@@ -18,34 +18,45 @@ type Entry struct {
 
 // Validate validates an entry according to OLF v2.0 rules
 func (e Entry) Validate(year, month int) error {
+	return firstViolation(e.ValidateAll(year, month))
+}
+
+// ValidateAll validates an entry and returns every violated rule instead of stopping at the first one
+func (e Entry) ValidateAll(year, month int) []Violation {
+	var violations []Violation
+
 	// E-2: Every Entry must include both amount and non-empty note fields
-	// E-3: If date is present, it must strictly follow the ISO-8601 YYYY-MM-DD format
 	err := validation.ValidateStruct(&e,
 		validation.Field(&e.Amount, validation.Required),
 		validation.Field(&e.Note, validation.Required, validation.Length(1, 0)),
-		validation.Field(&e.Date, validation.Date("2006-01-02").Error("E-3: date format must be YYYY-MM-DD")),
 	)
 	if err != nil {
-		return err
+		violations = append(violations, newViolation("E-2", 0, 0, "%s", strings.TrimSuffix(err.Error(), ".")))
+	}
+
+	// E-3: If date is present, it must strictly follow the ISO-8601 YYYY-MM-DD format
+	err = validation.Validate(e.Date, validation.Date("2006-01-02").Error("date format must be YYYY-MM-DD"))
+	if err != nil {
+		return append(violations, newViolation("E-3", 0, 0, "%v", err))
 	}
 
 	date, ok, err := e.ParseDate()
 	if err != nil {
-		return fmt.Errorf("E-3: invalid date format: %w", err)
+		return append(violations, newViolation("E-3", 0, 0, "invalid date format: %v", err))
 	}
 
 	// E-1: If an entry has a date, that date must lie within the year and month of its parent Month object
 	if ok {
 		if year != 0 && date.Year() != year {
-			return fmt.Errorf("E-1: entry date year does not match expected year (expected: %d, got: %d)", year, date.Year())
-		}
-
-		if month != 0 && int(date.Month()) != month {
-			return fmt.Errorf("E-1: entry date month does not match expected month (expected: %d, got: %d)", month, int(date.Month()))
+			violations = append(violations, newViolation("E-1", year, date.Year(),
+				"entry date year does not match expected year (expected: %d, got: %d)", year, date.Year()))
+		} else if month != 0 && int(date.Month()) != month {
+			violations = append(violations, newViolation("E-1", month, int(date.Month()),
+				"entry date month does not match expected month (expected: %d, got: %d)", month, int(date.Month())))
 		}
 	}
 
-	return nil
+	return violations
 }
 
 func (e Entry) ParseDate() (time.Time, bool, error) {
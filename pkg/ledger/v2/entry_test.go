@@ -48,7 +48,7 @@ func TestEntry_Validate(t *testing.T) {
 			year:    2025,
 			month:   1,
 			wantErr: true,
-			errMsg:  "amount: cannot be blank",
+			errMsg:  "E-2: amount: cannot be blank",
 		},
 		{
 			name: "missing note",
@@ -61,7 +61,7 @@ func TestEntry_Validate(t *testing.T) {
 			year:    2025,
 			month:   1,
 			wantErr: true,
-			errMsg:  "note: cannot be blank",
+			errMsg:  "E-2: note: cannot be blank",
 		},
 		{
 			name: "invalid date format",
@@ -75,7 +75,7 @@ func TestEntry_Validate(t *testing.T) {
 			year:    2025,
 			month:   1,
 			wantErr: true,
-			errMsg:  "date: E-3: date format must be YYYY-MM-DD",
+			errMsg:  "E-3: date format must be YYYY-MM-DD",
 		},
 		{
 			name: "invalid date value",
@@ -135,3 +135,13 @@ func TestEntry_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestEntry_ValidateAll_Messages(t *testing.T) {
+	violations := Entry{Date: "2025/01/15"}.ValidateAll(2025, 1)
+
+	require.Len(t, violations, 2)
+	assert.Equal(t, "E-2", violations[0].Rule)
+	assert.Equal(t, "E-2: amount: cannot be blank; note: cannot be blank", violations[0].Message)
+	assert.Equal(t, "E-3", violations[1].Rule)
+	assert.Equal(t, "E-3: date format must be YYYY-MM-DD", violations[1].Message)
+}
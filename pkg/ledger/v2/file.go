@@ -0,0 +1,87 @@
+package v2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic writes data to a temporary file in the same directory, syncs it to disk
+// and renames it over path, so that a crash never leaves a truncated file behind.
+// Up to backups previous versions of the file are kept as path.bak.1 (newest) to path.bak.N.
+func writeFileAtomic(path string, data []byte, backups int) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+
+		if backups > 0 {
+			if err := rotateBackups(path, backups, perm); err != nil {
+				return fmt.Errorf("failed to back up file: %w", err)
+			}
+		}
+	}
+
+	return replaceFile(path, data, perm)
+}
+
+// replaceFile writes data with the given permissions to a temporary file in the same
+// directory, syncs it to disk and renames it over path
+func replaceFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name()) // no-op after a successful rename
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// Persist the rename itself; not supported on every platform, so errors are ignored
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+
+	return nil
+}
+
+// rotateBackups shifts path.bak.1 .. path.bak.(n-1) up by one, dropping the oldest,
+// and copies the current file to path.bak.1 with the permissions of the current file
+func rotateBackups(path string, n int, perm os.FileMode) error {
+	backup := func(i int) string {
+		return fmt.Sprintf("%s.bak.%d", path, i)
+	}
+
+	if err := os.Remove(backup(n)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := n - 1; i >= 1; i-- {
+		if err := os.Rename(backup(i), backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return replaceFile(backup(1), data, perm)
+}
@@ -0,0 +1,96 @@
+package v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Run("new file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ledger.yaml")
+
+		require.NoError(t, writeFileAtomic(path, []byte("v1"), 3))
+		assertFile(t, path, "v1")
+		assert.NoFileExists(t, path+".bak.1")
+	})
+
+	t.Run("keeps file mode and leaves no temp files", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "ledger.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("v1"), 0600))
+
+		require.NoError(t, writeFileAtomic(path, []byte("v2"), 0))
+		assertFile(t, path, "v2")
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("rotates backups", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ledger.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("v1"), 0644))
+
+		for _, version := range []string{"v2", "v3", "v4", "v5"} {
+			require.NoError(t, writeFileAtomic(path, []byte(version), 3))
+		}
+
+		assertFile(t, path, "v5")
+		assertFile(t, path+".bak.1", "v4")
+		assertFile(t, path+".bak.2", "v3")
+		assertFile(t, path+".bak.3", "v2")
+		assert.NoFileExists(t, path+".bak.4")
+	})
+
+	t.Run("backups keep file mode", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ledger.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("v1"), 0600))
+
+		for _, version := range []string{"v2", "v3"} {
+			require.NoError(t, writeFileAtomic(path, []byte(version), 2))
+		}
+
+		for _, backup := range []string{path + ".bak.1", path + ".bak.2"} {
+			info, err := os.Stat(backup)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), backup)
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "ledger.yaml")
+		require.Error(t, writeFileAtomic(path, []byte("v1"), 0))
+	})
+}
+
+func TestWriteLedgerWithOptions_Backups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(annotatedYAML), 0644))
+
+	ledger := readYAMLString(t, annotatedYAML)
+	ledger.Years[2025].Months[1].Accounts["Checking"].Entries[0].Amount = 70
+	ledger, _ = ledger.Fix()
+
+	require.NoError(t, WriteLedgerWithOptions(ledger, path, WriteOptions{Backups: 1}))
+	assertFile(t, path+".bak.1", annotatedYAML)
+
+	written, err := ReadLedger(path)
+	require.NoError(t, err)
+	assert.Equal(t, ledger, written)
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(data))
+}
@@ -0,0 +1,84 @@
+package v2
+
+import "fmt"
+
+// Change is a single derived balance rewritten by Fix
+type Change struct {
+	Path  Path   // the year, month or account holding the balance
+	Field string // "opening_balance" or "closing_balance"
+	Old   int
+	New   int
+}
+
+// String returns the change in the form "year 2024: month 7: account Savings: closing_balance: 100 -> 150"
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %d -> %d", c.Path, c.Field, c.Old, c.New)
+}
+
+// Fix recomputes every derived balance of the ledger so that rules A-1, A-2, A-3, M-1, M-2, M-3
+// and Y-1 to Y-3 hold. Entries and the opening balances of the accounts in the very first month
+// are treated as the source of truth. It returns the fixed ledger and the list of changed balances;
+// the receiver is left untouched.
+func (l Ledger) Fix() (Ledger, []Change) {
+	fixed := Ledger{Currency: l.Currency, Budgets: l.Budgets, Years: make(map[int]Year, len(l.Years))}
+	var changes []Change
+
+	update := func(path Path, field string, value *int, want int) {
+		if *value != want {
+			changes = append(changes, Change{Path: path, Field: field, Old: *value, New: want})
+			*value = want
+		}
+	}
+
+	var prevMonth *Month
+	for _, yearNum := range l.GetYearNumbers() {
+		year := l.Years[yearNum]
+		year.Months = make(map[int]Month, len(year.Months))
+
+		for _, monthNum := range l.Years[yearNum].GetMonthNumbers() {
+			month := l.Years[yearNum].Months[monthNum]
+			month.Accounts = make(map[string]Account, len(month.Accounts))
+
+			openingSum, closingSum := 0, 0
+			for _, accountName := range l.Years[yearNum].Months[monthNum].GetAccountNames() {
+				account := l.Years[yearNum].Months[monthNum].Accounts[accountName]
+				path := Path{Year: yearNum, Month: monthNum, Account: accountName}
+
+				if prevMonth != nil {
+					// A-2 and A-3: continue from the previous month, or start a new account at 0
+					opening := 0
+					if prevAccount, ok := prevMonth.Accounts[accountName]; ok {
+						opening = prevAccount.ClosingBalance
+					}
+					update(path, "opening_balance", &account.OpeningBalance, opening)
+				}
+
+				// A-1
+				update(path, "closing_balance", &account.ClosingBalance, account.OpeningBalance+account.EntriesSum())
+
+				openingSum += account.OpeningBalance
+				closingSum += account.ClosingBalance
+				month.Accounts[accountName] = account
+			}
+
+			// M-2, M-3 and, since accounts chain, M-1
+			path := Path{Year: yearNum, Month: monthNum}
+			update(path, "opening_balance", &month.OpeningBalance, openingSum)
+			update(path, "closing_balance", &month.ClosingBalance, closingSum)
+
+			year.Months[monthNum] = month
+			prevMonth = &month
+		}
+
+		// Y-2, Y-3 and, since months chain, Y-1
+		if monthNums := year.GetMonthNumbers(); len(monthNums) > 0 {
+			path := Path{Year: yearNum}
+			update(path, "opening_balance", &year.OpeningBalance, year.Months[monthNums[0]].OpeningBalance)
+			update(path, "closing_balance", &year.ClosingBalance, year.Months[monthNums[len(monthNums)-1]].ClosingBalance)
+		}
+
+		fixed.Years[yearNum] = year
+	}
+
+	return fixed, changes
+}
@@ -0,0 +1,124 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger_Fix(t *testing.T) {
+	// A valid ledger in which the January salary was edited from 200 to 250
+	// and a new account was added in February with a wrong opening balance
+	ledger := Ledger{
+		Years: map[int]Year{
+			2024: {
+				OpeningBalance: 1000,
+				ClosingBalance: 1150,
+				Months: map[int]Month{
+					1: {
+						OpeningBalance: 1000,
+						ClosingBalance: 1100,
+						Accounts: map[string]Account{
+							"Checking": {
+								OpeningBalance: 600,
+								ClosingBalance: 700,
+								Entries: []Entry{
+									{Amount: 250, Note: "Salary", Date: "2024-01-15"},
+									{Amount: -100, Note: "Rent", Date: "2024-01-01"},
+								},
+							},
+							"Savings": {OpeningBalance: 400, ClosingBalance: 400},
+						},
+					},
+					2: {
+						OpeningBalance: 1100,
+						ClosingBalance: 1150,
+						Accounts: map[string]Account{
+							"Checking": {
+								OpeningBalance: 700,
+								ClosingBalance: 750,
+								Entries: []Entry{
+									{Amount: 50, Note: "Refund", Date: "2024-02-10"},
+								},
+							},
+							"Savings": {OpeningBalance: 400, ClosingBalance: 400},
+							"Broker":  {OpeningBalance: 20, ClosingBalance: 20},
+						},
+					},
+				},
+			},
+			2025: {
+				OpeningBalance: 1150,
+				ClosingBalance: 1150,
+				Months: map[int]Month{
+					1: {
+						OpeningBalance: 1150,
+						ClosingBalance: 1150,
+						Accounts: map[string]Account{
+							"Checking": {OpeningBalance: 750, ClosingBalance: 750},
+							"Savings":  {OpeningBalance: 400, ClosingBalance: 400},
+						},
+					},
+				},
+			},
+		},
+	}
+	require.Error(t, ledger.Validate())
+
+	fixed, changes := ledger.Fix()
+	require.NoError(t, fixed.Validate())
+
+	assert.Equal(t, []string{
+		"year 2024: month 1: account Checking: closing_balance: 700 -> 750",
+		"year 2024: month 1: closing_balance: 1100 -> 1150",
+		"year 2024: month 2: account Broker: opening_balance: 20 -> 0",
+		"year 2024: month 2: account Broker: closing_balance: 20 -> 0",
+		"year 2024: month 2: account Checking: opening_balance: 700 -> 750",
+		"year 2024: month 2: account Checking: closing_balance: 750 -> 800",
+		"year 2024: month 2: opening_balance: 1100 -> 1150",
+		"year 2024: month 2: closing_balance: 1150 -> 1200",
+		"year 2024: closing_balance: 1150 -> 1200",
+		"year 2025: month 1: account Checking: opening_balance: 750 -> 800",
+		"year 2025: month 1: account Checking: closing_balance: 750 -> 800",
+		"year 2025: month 1: opening_balance: 1150 -> 1200",
+		"year 2025: month 1: closing_balance: 1150 -> 1200",
+		"year 2025: opening_balance: 1150 -> 1200",
+		"year 2025: closing_balance: 1150 -> 1200",
+	}, lo.Map(changes, func(change Change, _ int) string {
+		return change.String()
+	}))
+
+	// The original ledger is left untouched
+	assert.Equal(t, 700, ledger.Years[2024].Months[1].Accounts["Checking"].ClosingBalance)
+	assert.Equal(t, 1150, ledger.Years[2025].OpeningBalance)
+}
+
+func TestLedger_Fix_ValidLedgerUnchanged(t *testing.T) {
+	ledger := Ledger{
+		Years: map[int]Year{
+			2025: {
+				OpeningBalance: 100,
+				ClosingBalance: 150,
+				Months: map[int]Month{
+					1: {
+						OpeningBalance: 100,
+						ClosingBalance: 150,
+						Accounts: map[string]Account{
+							"Checking": {
+								OpeningBalance: 100,
+								ClosingBalance: 150,
+								Entries:        []Entry{{Amount: 50, Note: "Salary"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fixed, changes := ledger.Fix()
+	assert.Empty(t, changes)
+	assert.Equal(t, ledger, fixed)
+}
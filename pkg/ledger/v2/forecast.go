@@ -0,0 +1,114 @@
+package v2
+
+import "math"
+
+// ScheduledItem is a known future income (positive) or expense (negative) in a month
+type ScheduledItem struct {
+	YearMonth
+	Amount int
+	Note   string
+}
+
+// ForecastOptions configures a cash-flow projection
+type ForecastOptions struct {
+	// Months is the number of months projected after the latest month of the ledger
+	Months int
+	// Window is the number of latest ledger months averaged; zero or more than the ledger holds averages all months
+	Window int
+	// Scheduled are known items added to the projected months on top of the averages
+	Scheduled []ScheduledItem
+}
+
+// ForecastMonth is a projected month
+type ForecastMonth struct {
+	YearMonth
+	// Income and Expenses are the historical averages
+	Income   int
+	Expenses int
+	// Scheduled holds the scheduled items of the month
+	Scheduled []ScheduledItem
+	// ClosingBalance is the projected balance across all accounts at the end of the month
+	ClosingBalance int
+}
+
+// ScheduledTotal returns the sum of the month's scheduled items
+func (f ForecastMonth) ScheduledTotal() int {
+	total := 0
+	for _, item := range f.Scheduled {
+		total += item.Amount
+	}
+	return total
+}
+
+// Net returns the projected change of the balance over the month
+func (f ForecastMonth) Net() int {
+	return f.Income + f.Expenses + f.ScheduledTotal()
+}
+
+// Forecast is a projection of month-end balances from historical averages
+type Forecast struct {
+	// History holds the ledger months the averages are computed from, in order
+	History []YearMonth
+	// Income and Expenses are the average income and expenses per month over the history
+	Income   int
+	Expenses int
+	// Tags holds the average total per month of each tag over the history, excluding internal entries
+	Tags map[string]int
+	// Months holds the projected months following the latest month of the ledger
+	Months []ForecastMonth
+}
+
+// Forecast projects the balances of the months following the latest month of the ledger,
+// assuming every month brings the average income and expenses of the latest months plus
+// the scheduled items. Scheduled items outside the projected months are ignored.
+func (l Ledger) Forecast(opts ForecastOptions) Forecast {
+	months := l.GetMonths()
+	result := Forecast{Tags: make(map[string]int)}
+	if len(months) == 0 {
+		return result
+	}
+
+	window := opts.Window
+	if window <= 0 || window > len(months) {
+		window = len(months)
+	}
+	result.History = months[len(months)-window:]
+
+	income, expenses := 0, 0
+	tags := make(map[string]int)
+	for _, ym := range result.History {
+		month := l.Years[ym.Year].Months[ym.Month]
+		income += month.Income()
+		expenses += month.Expenses()
+		for tag, amount := range month.TagTotals() {
+			tags[tag] += amount
+		}
+	}
+	result.Income = average(income, window)
+	result.Expenses = average(expenses, window)
+	for tag, amount := range tags {
+		result.Tags[tag] = average(amount, window)
+	}
+
+	latest := months[len(months)-1]
+	balance := l.Years[latest.Year].Months[latest.Month].ClosingBalance
+	for i := 1; i <= opts.Months; i++ {
+		month := ForecastMonth{YearMonth: latest.AddMonths(i), Income: result.Income, Expenses: result.Expenses}
+		for _, item := range opts.Scheduled {
+			if item.YearMonth == month.YearMonth {
+				month.Scheduled = append(month.Scheduled, item)
+			}
+		}
+
+		balance += month.Net()
+		month.ClosingBalance = balance
+		result.Months = append(result.Months, month)
+	}
+
+	return result
+}
+
+// average returns sum / n rounded half away from zero
+func average(sum, n int) int {
+	return int(math.Round(float64(sum) / float64(n)))
+}
@@ -0,0 +1,88 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastMonth_Net(t *testing.T) {
+	month := ForecastMonth{Income: 300, Expenses: -200, Scheduled: []ScheduledItem{{Amount: -1000}, {Amount: 50}}}
+	assert.Equal(t, -950, month.ScheduledTotal())
+	assert.Equal(t, -850, month.Net())
+	assert.Equal(t, 100, ForecastMonth{Income: 300, Expenses: -200}.Net())
+}
+
+func TestLedger_Forecast(t *testing.T) {
+	ledger := Ledger{Years: map[int]Year{
+		2024: {Months: map[int]Month{
+			11: testMonth(575, Entry{Amount: 900, Note: "Salary", Tag: "Income"}, Entry{Amount: -900, Note: "Rent", Tag: "Housing"}),
+			12: testMonth(575, Entry{Amount: 300, Note: "Salary", Tag: "Income"}, Entry{Amount: -200, Note: "Rent", Tag: "Housing"}),
+		}},
+		2025: {Months: map[int]Month{
+			1: testMonth(675, Entry{Amount: 300, Note: "Salary", Tag: "Income"}, Entry{Amount: -150, Note: "Rent", Tag: "Housing"},
+				Entry{Amount: -50, Note: "Cinema"}, Entry{Amount: -75, Note: "To savings", Internal: true}),
+		}},
+	}}
+	insurance := ScheduledItem{YearMonth: YearMonth{2025, 4}, Amount: -1000, Note: "Insurance"}
+	refund := ScheduledItem{YearMonth: YearMonth{2025, 4}, Amount: 50, Note: "Tax refund"}
+
+	tests := []struct {
+		name   string
+		ledger Ledger
+		opts   ForecastOptions
+		want   Forecast
+	}{
+		{
+			name:   "window with scheduled items",
+			ledger: ledger,
+			opts: ForecastOptions{
+				Months: 3,
+				Window: 2,
+				Scheduled: []ScheduledItem{
+					insurance,
+					refund,
+					{YearMonth: YearMonth{2026, 1}, Amount: -999, Note: "Beyond the forecast"},
+				},
+			},
+			want: Forecast{
+				History:  []YearMonth{{2024, 12}, {2025, 1}},
+				Income:   300,
+				Expenses: -200,
+				Tags:     map[string]int{"Income": 300, "Housing": -175, "": -25},
+				Months: []ForecastMonth{
+					{YearMonth: YearMonth{2025, 2}, Income: 300, Expenses: -200, ClosingBalance: 800},
+					{YearMonth: YearMonth{2025, 3}, Income: 300, Expenses: -200, ClosingBalance: 900},
+					{YearMonth: YearMonth{2025, 4}, Income: 300, Expenses: -200, Scheduled: []ScheduledItem{insurance, refund}, ClosingBalance: 50},
+				},
+			},
+		},
+		{
+			// Without a window every month is averaged
+			name:   "all months",
+			ledger: ledger,
+			opts:   ForecastOptions{Months: 1},
+			want: Forecast{
+				History:  []YearMonth{{2024, 11}, {2024, 12}, {2025, 1}},
+				Income:   500,
+				Expenses: -433,
+				Tags:     map[string]int{"Income": 500, "Housing": -417, "": -17},
+				Months: []ForecastMonth{
+					{YearMonth: YearMonth{2025, 2}, Income: 500, Expenses: -433, ClosingBalance: 767},
+				},
+			},
+		},
+		{
+			name:   "empty ledger",
+			ledger: Ledger{},
+			opts:   ForecastOptions{Months: 12},
+			want:   Forecast{Tags: map[string]int{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ledger.Forecast(tt.opts))
+		})
+	}
+}
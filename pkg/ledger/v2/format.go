@@ -0,0 +1,28 @@
+package v2
+
+import (
+	"io"
+	"ledger/pkg/olfio"
+	"os"
+)
+
+// Format is the encoding of a ledger file
+type Format = olfio.Format
+
+const (
+	FormatYAML = olfio.YAML
+	FormatJSON = olfio.JSON
+	FormatTOML = olfio.TOML
+)
+
+// StdioPath is the path that reads from standard input and writes to standard output
+const StdioPath = olfio.StdioPath
+
+// stdinName is the file name reported in source locations of ledgers read from standard input
+const stdinName = "<stdin>"
+
+// stdin and stdout are replaced in tests
+var (
+	stdin  io.Reader = os.Stdin
+	stdout io.Writer = os.Stdout
+)
@@ -0,0 +1,90 @@
+package v2
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLedgerWithOptions(t *testing.T) {
+	jsonContent := `{"years": {"2025": {"months": {"1": {"accounts": {"Checking": {"opening_balance": 100, "closing_balance": 100}}, "opening_balance": 100, "closing_balance": 100}}, "opening_balance": 100, "closing_balance": 100}}}`
+
+	t.Run("format override", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ledger.dec")
+		require.NoError(t, os.WriteFile(path, []byte(jsonContent), 0644))
+
+		ledger, source, err := ReadLedgerWithOptions(path, ReadOptions{Format: FormatJSON})
+		require.NoError(t, err)
+		assert.Equal(t, 100, ledger.Years[2025].Months[1].Accounts["Checking"].ClosingBalance)
+
+		loc, ok := source.LocateField(Path{Year: 2025}, "closing_balance")
+		require.True(t, ok)
+		assert.Equal(t, path, loc.File)
+	})
+
+	t.Run("invalid format override", func(t *testing.T) {
+		_, _, err := ReadLedgerWithOptions("ledger.yaml", ReadOptions{Format: "xml"})
+		require.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		stdin = strings.NewReader(jsonContent)
+		defer func() { stdin = os.Stdin }()
+
+		ledger, source, err := ReadLedgerWithOptions(StdioPath, ReadOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 100, ledger.Years[2025].ClosingBalance)
+		assert.Equal(t, FormatJSON, source.Format())
+
+		loc, ok := source.LocateField(Path{Year: 2025, Month: 1, Account: "Checking"}, "")
+		require.True(t, ok)
+		assert.Equal(t, "<stdin>", loc.File)
+	})
+
+	t.Run("stdin with TOML format", func(t *testing.T) {
+		stdin = strings.NewReader("[years.2025]\nopening_balance = 5\nclosing_balance = 5\n")
+		defer func() { stdin = os.Stdin }()
+
+		ledger, source, err := ReadLedgerWithOptions(StdioPath, ReadOptions{Format: FormatTOML})
+		require.NoError(t, err)
+		assert.Equal(t, 5, ledger.Years[2025].OpeningBalance)
+		assert.Equal(t, FormatTOML, source.Format())
+	})
+}
+
+func TestWriteLedgerWithOptions_Format(t *testing.T) {
+	ledger := Ledger{Years: map[int]Year{2025: {OpeningBalance: 10, ClosingBalance: 10}}}
+
+	t.Run("format override", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ledger.out")
+		require.NoError(t, WriteLedgerWithOptions(ledger, path, WriteOptions{Format: FormatTOML}))
+
+		read, _, err := ReadLedgerWithOptions(path, ReadOptions{Format: FormatTOML})
+		require.NoError(t, err)
+		assert.Equal(t, 10, read.Years[2025].ClosingBalance)
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		var buf bytes.Buffer
+		stdout = &buf
+		defer func() { stdout = os.Stdout }()
+
+		require.NoError(t, WriteLedgerWithOptions(ledger, StdioPath, WriteOptions{Format: FormatJSON, Backups: 3}))
+		assert.Contains(t, buf.String(), `"opening_balance": 10`)
+	})
+
+	t.Run("stdout defaults to YAML", func(t *testing.T) {
+		var buf bytes.Buffer
+		stdout = &buf
+		defer func() { stdout = os.Stdout }()
+
+		require.NoError(t, WriteLedger(ledger, StdioPath))
+		assert.Contains(t, buf.String(), "opening_balance: 10")
+		assert.NoFileExists(t, StdioPath)
+	})
+}
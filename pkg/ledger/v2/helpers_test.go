@@ -0,0 +1,13 @@
+package v2
+
+// testMonth returns a month with a single "Checking" account holding the entries,
+// closing with the opening balance plus the sum of the entries
+func testMonth(opening int, entries ...Entry) Month {
+	closing := opening
+	for _, e := range entries {
+		closing += e.Amount
+	}
+	return Month{OpeningBalance: opening, ClosingBalance: closing, Accounts: map[string]Account{
+		"Checking": {OpeningBalance: opening, ClosingBalance: closing, Entries: entries},
+	}}
+}
@@ -0,0 +1,48 @@
+package v2
+
+// AccountMonth is the state of an account in a single month of the ledger
+type AccountMonth struct {
+	Year    int
+	Month   int
+	Account Account
+	// Opened is set when the account is absent from the previous month of the ledger (A-3)
+	Opened bool
+	// Closed is set when the account is omitted from the following month of the ledger (A-4)
+	Closed bool
+}
+
+// AccountHistory returns the months of every account in chronological order, keyed by account name.
+// Accounts of the very first month are not marked as opened, and accounts of the last month
+// are not marked as closed.
+func (l Ledger) AccountHistory() map[string][]AccountMonth {
+	type monthRef struct {
+		year, month int
+		data        Month
+	}
+
+	var months []monthRef
+	for _, yearNum := range l.GetYearNumbers() {
+		year := l.Years[yearNum]
+		for _, monthNum := range year.GetMonthNumbers() {
+			months = append(months, monthRef{year: yearNum, month: monthNum, data: year.Months[monthNum]})
+		}
+	}
+
+	history := make(map[string][]AccountMonth)
+	for i, m := range months {
+		for _, name := range m.data.GetAccountNames() {
+			entry := AccountMonth{Year: m.year, Month: m.month, Account: m.data.Accounts[name]}
+			if i > 0 {
+				_, ok := months[i-1].data.Accounts[name]
+				entry.Opened = !ok
+			}
+			if i+1 < len(months) {
+				_, ok := months[i+1].data.Accounts[name]
+				entry.Closed = !ok
+			}
+			history[name] = append(history[name], entry)
+		}
+	}
+
+	return history
+}
@@ -0,0 +1,68 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger_AccountHistory(t *testing.T) {
+	checking := Account{OpeningBalance: 100, ClosingBalance: 100}
+	closed := Account{OpeningBalance: 0, ClosingBalance: 0}
+	savings := Account{OpeningBalance: 0, ClosingBalance: 50, Entries: []Entry{{Amount: 50, Note: "Deposit"}}}
+
+	tests := []struct {
+		name   string
+		ledger Ledger
+		want   map[string][]AccountMonth
+	}{
+		{
+			name: "opened and closed accounts",
+			ledger: Ledger{Years: map[int]Year{
+				2024: {Months: map[int]Month{
+					12: {Accounts: map[string]Account{"Checking": checking, "Old": closed}},
+				}},
+				2025: {Months: map[int]Month{
+					1: {Accounts: map[string]Account{"Checking": checking, "Savings": savings}},
+					2: {Accounts: map[string]Account{"Checking": checking, "Savings": savings}},
+				}},
+			}},
+			want: map[string][]AccountMonth{
+				"Checking": {
+					{Year: 2024, Month: 12, Account: checking},
+					{Year: 2025, Month: 1, Account: checking},
+					{Year: 2025, Month: 2, Account: checking},
+				},
+				"Old": {
+					{Year: 2024, Month: 12, Account: closed, Closed: true},
+				},
+				"Savings": {
+					{Year: 2025, Month: 1, Account: savings, Opened: true},
+					{Year: 2025, Month: 2, Account: savings},
+				},
+			},
+		},
+		{
+			name: "single month",
+			ledger: Ledger{Years: map[int]Year{
+				2025: {Months: map[int]Month{
+					1: {Accounts: map[string]Account{"Checking": checking}},
+				}},
+			}},
+			want: map[string][]AccountMonth{
+				"Checking": {{Year: 2025, Month: 1, Account: checking}},
+			},
+		},
+		{
+			name:   "empty ledger",
+			ledger: Ledger{},
+			want:   map[string][]AccountMonth{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ledger.AccountHistory())
+		})
+	}
+}
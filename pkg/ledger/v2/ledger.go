@@ -2,40 +2,58 @@ package v2
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"ledger/pkg/olfio"
 	"os"
-	"path/filepath"
 	"sort"
-	"strings"
 
 	"github.com/samber/lo"
 	"gopkg.in/yaml.v3"
 )
 
+var (
+	// ErrRead is returned when a ledger file cannot be read
+	ErrRead = errors.New("failed to read file")
+	// ErrParse is returned when a ledger file is not valid YAML, JSON or TOML for the ledger structure
+	ErrParse = errors.New("failed to parse file")
+	// ErrWrite is returned when a ledger file cannot be written
+	ErrWrite = errors.New("failed to write file")
+	// ErrUnsupportedFormat is returned when the file extension does not match a supported format
+	ErrUnsupportedFormat = olfio.ErrUnsupportedFormat
+)
+
 // Ledger represents the root structure of the Open Ledger Format v2.0
 type Ledger struct {
-	Years map[int]Year `json:"years" yaml:"years" toml:"years"`
+	// Currency optionally sets how amounts are displayed in reports
+	Currency *Currency `json:"currency,omitempty" yaml:"currency,omitempty" toml:"currency,omitempty"`
+	// Budgets optionally plan the spending per tag; see Budget
+	Budgets []Budget     `json:"budgets,omitempty" yaml:"budgets,omitempty" toml:"budgets,omitempty"`
+	Years   map[int]Year `json:"years" yaml:"years" toml:"years"`
 }
 
 // Validate validates the entire ledger according to OLF v2.0 rules
 func (l Ledger) Validate() error {
-	// Get sorted year numbers for consistent validation order
-	yearNums := lo.Keys(l.Years)
-	sort.Ints(yearNums)
+	return firstViolation(l.ValidateAll())
+}
 
-	// Validate all years
+// ValidateAll validates the entire ledger and returns every violated rule instead of stopping at the first one
+func (l Ledger) ValidateAll() []Violation {
+	// Validate all years in order
+	var violations []Violation
 	var prevYear *Year
-	for _, yearNum := range yearNums {
+	for _, yearNum := range l.GetYearNumbers() {
 		year := l.Years[yearNum]
 
-		if err := year.Validate(yearNum, prevYear); err != nil {
-			return fmt.Errorf("year %d: %w", yearNum, err)
+		for _, violation := range year.ValidateAll(yearNum, prevYear) {
+			violation.Path.Year = yearNum
+			violations = append(violations, violation)
 		}
 
 		prevYear = &year
 	}
 
-	return nil
+	return append(violations, l.validateBudgets()...)
 }
 
 // Income returns the total income across all years
@@ -52,51 +70,132 @@ func (l Ledger) Expenses() int {
 	})
 }
 
+// GetYearNumbers returns sorted list of year numbers
+func (l Ledger) GetYearNumbers() []int {
+	yearNums := lo.Keys(l.Years)
+	sort.Ints(yearNums)
+	return yearNums
+}
+
 // ReadLedger reads and parses a ledger file in YAML, JSON, or TOML format
 func ReadLedger(path string) (Ledger, error) {
-	bytes, err := os.ReadFile(path)
+	ledger, _, err := ReadLedgerWithSource(path)
+	return ledger, err
+}
+
+// ReadLedgerWithSource reads and parses a ledger file like ReadLedger,
+// also returning a SourceMap to locate violations in the file
+func ReadLedgerWithSource(path string) (Ledger, SourceMap, error) {
+	return ReadLedgerWithOptions(path, ReadOptions{})
+}
+
+// ReadOptions controls how a ledger file is read
+type ReadOptions struct {
+	// Format overrides the format detected from the file extension
+	Format Format
+}
+
+// ReadLedgerWithOptions reads and parses a ledger file like ReadLedgerWithSource.
+// The path "-" reads from standard input, as JSON if it starts with '{' and as YAML otherwise.
+func ReadLedgerWithOptions(path string, opts ReadOptions) (Ledger, SourceMap, error) {
+	bytes, format, err := olfio.ReadInput(path, opts.Format, stdin)
+	if errors.Is(err, ErrUnsupportedFormat) {
+		return Ledger{}, SourceMap{}, err
+	}
 	if err != nil {
-		return Ledger{}, fmt.Errorf("failed to read file: %w", err)
+		return Ledger{}, SourceMap{}, fmt.Errorf("%w: %w", ErrRead, err)
+	}
+
+	file := path
+	if path == StdioPath {
+		file = stdinName
 	}
 
 	ledger := Ledger{}
-	switch strings.ToLower(filepath.Ext(path)) {
-	case ".json":
+	var source SourceMap
+	switch format {
+	case FormatJSON:
 		err = json.Unmarshal(bytes, &ledger)
-	case ".yaml", ".yml":
+		if err == nil {
+			source, err = newJSONSourceMap(file, bytes)
+		}
+	case FormatYAML:
 		err = yaml.Unmarshal(bytes, &ledger)
-	default:
-		return Ledger{}, fmt.Errorf("unsupported file format: %s", filepath.Ext(path))
+		if err == nil {
+			source, err = newYAMLSourceMap(file, bytes)
+		}
+	case FormatTOML:
+		// No positions are kept for TOML, violations are reported without locations
+		err = unmarshalTOML(bytes, &ledger)
+		source = SourceMap{file: file}
 	}
 
 	if err != nil {
-		return Ledger{}, fmt.Errorf("failed to parse file: %w", err)
+		return Ledger{}, SourceMap{}, fmt.Errorf("%w: %w", ErrParse, err)
 	}
+	source.format = format
 
-	return ledger, nil
+	return ledger, source, nil
 }
 
-// WriteLedger writes a ledger to a file in the specified format
+// WriteOptions controls how a ledger file is written
+type WriteOptions struct {
+	// Backups is the number of rotating backups kept when overwriting an existing file,
+	// from path.bak.1 (newest) to path.bak.N (oldest). Zero disables backups.
+	Backups int
+	// Format overrides the format detected from the file extension
+	Format Format
+}
+
+// WriteLedger writes a ledger to a file in the specified format without keeping backups.
+// See WriteLedgerWithOptions.
 func WriteLedger(ledger Ledger, path string) error {
-	var data []byte
-	var err error
+	return WriteLedgerWithOptions(ledger, path, WriteOptions{})
+}
 
-	switch strings.ToLower(filepath.Ext(path)) {
-	case ".json":
+// WriteLedgerWithOptions writes a ledger to a file in the specified format.
+// The file is replaced atomically, so a failed write never leaves a truncated ledger behind.
+// When overwriting an existing YAML file, only changed values are rewritten
+// and its comments, key order and entry styles are preserved.
+// The path "-" writes to standard output, without backups.
+func WriteLedgerWithOptions(ledger Ledger, path string, opts WriteOptions) error {
+	format, err := olfio.ResolveFormat(path, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	switch format {
+	case FormatJSON:
 		data, err = json.MarshalIndent(ledger, "", "  ")
-	case ".yaml", ".yml":
-		data, err = yaml.Marshal(ledger)
-	default:
-		return fmt.Errorf("unsupported file format: %s", filepath.Ext(path))
+	case FormatYAML:
+		var existing []byte
+		readErr := os.ErrNotExist
+		if path != StdioPath {
+			existing, readErr = os.ReadFile(path)
+		}
+		if readErr == nil {
+			data, err = updateYAML(existing, ledger)
+		}
+		if readErr != nil || err != nil {
+			// Nothing to preserve, or the existing file is not valid YAML: write from scratch
+			data, err = yaml.Marshal(ledger)
+		}
+	case FormatTOML:
+		data, err = marshalTOML(ledger)
 	}
 
 	if err != nil {
 		return fmt.Errorf("failed to marshal ledger: %w", err)
 	}
 
-	err = os.WriteFile(path, data, 0644)
+	if path == StdioPath {
+		_, err = stdout.Write(data)
+	} else {
+		err = writeFileAtomic(path, data, opts.Backups)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return fmt.Errorf("%w: %w", ErrWrite, err)
 	}
 
 	return nil
@@ -3,7 +3,6 @@ package v2
 import (
 	"os"
 	"path/filepath"
-	"sort"
 	"testing"
 
 	"github.com/samber/lo"
@@ -304,7 +303,73 @@ func TestLedger_GetYearNumbers(t *testing.T) {
 		},
 	}
 
-	yearNums := lo.Keys(ledger.Years)
-	sort.Ints(yearNums)
-	assert.Equal(t, []int{2023, 2024, 2025}, yearNums) // Should be sorted
+	assert.Equal(t, []int{2023, 2024, 2025}, ledger.GetYearNumbers()) // Should be sorted
+}
+
+func TestLedger_ValidateAll(t *testing.T) {
+	ledger := Ledger{
+		Years: map[int]Year{
+			2024: {
+				OpeningBalance: 1000,
+				ClosingBalance: 1100,
+				Months: map[int]Month{
+					1: {
+						OpeningBalance: 1000,
+						ClosingBalance: 1100,
+						Accounts: map[string]Account{
+							"Checking": {
+								OpeningBalance: 1000,
+								ClosingBalance: 1100,
+								Entries: []Entry{
+									{Amount: 50, Note: "Salary", Date: "2024-02-01"}, // E-1 and A-1
+								},
+							},
+						},
+					},
+				},
+			},
+			2025: {
+				OpeningBalance: 1200, // Y-1
+				ClosingBalance: 1200,
+				Months: map[int]Month{
+					1: {
+						OpeningBalance: 1100, // M-2
+						ClosingBalance: 1200,
+						Accounts: map[string]Account{
+							"Checking": {
+								OpeningBalance: 1000, // A-2
+								ClosingBalance: 1200,
+								Entries: []Entry{
+									{Amount: 200, Note: "Salary", Date: "2025-01-15"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	violations := ledger.ValidateAll()
+	require.Len(t, violations, 6)
+
+	messages := lo.Map(violations, func(violation Violation, _ int) string {
+		return violation.Error()
+	})
+	assert.Contains(t, messages[0], "year 2024: month 1: account Checking: entry 0: E-1:")
+	assert.Contains(t, messages[1], "year 2024: month 1: account Checking: A-1:")
+	assert.Contains(t, messages[2], "year 2025: Y-1:")
+	assert.Contains(t, messages[3], "year 2025: month 1: account Checking: A-2:")
+	assert.Contains(t, messages[4], "year 2025: month 1: M-2:")
+	assert.Contains(t, messages[5], "year 2025: Y-2:")
+
+	assert.Equal(t, "A-2", violations[3].Rule)
+	assert.Equal(t, Path{Year: 2025, Month: 1, Account: "Checking"}, violations[3].Path)
+	assert.Equal(t, 1100, violations[3].Expected)
+	assert.Equal(t, 1000, violations[3].Actual)
+
+	// Validate reports the first of the collected violations
+	err := ledger.Validate()
+	require.Error(t, err)
+	assert.Equal(t, messages[0], err.Error())
 }
@@ -0,0 +1,9 @@
+package v2
+
+// Abs returns the magnitude of an amount
+func Abs(amount int) int {
+	if amount < 0 {
+		return -amount
+	}
+	return amount
+}
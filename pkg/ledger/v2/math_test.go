@@ -0,0 +1,22 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAbs(t *testing.T) {
+	tests := []struct {
+		amount int
+		want   int
+	}{
+		{amount: -5, want: 5},
+		{amount: 5, want: 5},
+		{amount: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Abs(tt.amount))
+	}
+}
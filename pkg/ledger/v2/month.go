@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"fmt"
 	"sort"
 
 	"github.com/samber/lo"
@@ -16,18 +15,27 @@ type Month struct {
 
 // Validate validates a month according to OLF v2.0 rules
 func (m Month) Validate(year, monthNum int, prevMonth *Month) error {
+	return firstViolation(m.ValidateAll(year, monthNum, prevMonth))
+}
+
+// ValidateAll validates a month and returns every violated rule instead of stopping at the first one
+func (m Month) ValidateAll(year, monthNum int, prevMonth *Month) []Violation {
 	// M-0: Month key (monthNum) must be between 1 and 12 (inclusive)
 	if monthNum < 1 || monthNum > 12 {
-		return fmt.Errorf("M-0: month number must be between 1 and 12 (got: %d)", monthNum)
+		return []Violation{newViolation("M-0", 0, monthNum, "month number must be between 1 and 12 (got: %d)", monthNum)}
 	}
 
 	// M-5: A Month must contain at least one Account entry
 	if len(m.Accounts) == 0 {
-		return fmt.Errorf("M-5: month must contain at least one account")
+		return []Violation{newViolation("M-5", 0, 0, "month must contain at least one account")}
 	}
 
-	// Validate accounts
-	for accountName, account := range m.Accounts {
+	var violations []Violation
+
+	// Validate accounts in sorted order so that results are deterministic
+	for _, accountName := range m.GetAccountNames() {
+		account := m.Accounts[accountName]
+
 		var prevAccount *Account
 		if prevMonth != nil {
 			if val, ok := prevMonth.Accounts[accountName]; ok {
@@ -35,8 +43,9 @@ func (m Month) Validate(year, monthNum int, prevMonth *Month) error {
 			}
 		}
 
-		if err := account.Validate(year, monthNum, prevAccount, prevMonth != nil); err != nil {
-			return fmt.Errorf("account %s: %w", accountName, err)
+		for _, violation := range account.ValidateAll(year, monthNum, prevAccount, prevMonth != nil) {
+			violation.Path.Account = accountName
+			violations = append(violations, violation)
 		}
 	}
 
@@ -45,8 +54,9 @@ func (m Month) Validate(year, monthNum int, prevMonth *Month) error {
 		return account.OpeningBalance
 	})
 	if m.OpeningBalance != accountsOpeningSum {
-		return fmt.Errorf("M-2: month opening balance does not equal sum of account opening balances (expected: %d, got: %d)",
-			accountsOpeningSum, m.OpeningBalance)
+		violations = append(violations, newViolation("M-2", accountsOpeningSum, m.OpeningBalance,
+			"month opening balance does not equal sum of account opening balances (expected: %d, got: %d)",
+			accountsOpeningSum, m.OpeningBalance))
 	}
 
 	// M-3: Month closing_balance equals sum of all account closing_balance values
@@ -54,8 +64,9 @@ func (m Month) Validate(year, monthNum int, prevMonth *Month) error {
 		return account.ClosingBalance
 	})
 	if m.ClosingBalance != accountsClosingSum {
-		return fmt.Errorf("M-3: month closing balance does not equal sum of account closing balances (expected: %d, got: %d)",
-			accountsClosingSum, m.ClosingBalance)
+		violations = append(violations, newViolation("M-3", accountsClosingSum, m.ClosingBalance,
+			"month closing balance does not equal sum of account closing balances (expected: %d, got: %d)",
+			accountsClosingSum, m.ClosingBalance))
 	}
 
 	// M-4: Within each month, Σ(entry.amount where internal = true) must equal 0 (double-entry constraint)
@@ -63,28 +74,34 @@ func (m Month) Validate(year, monthNum int, prevMonth *Month) error {
 		return account.InternalEntriesSum()
 	})
 	if internalSum != 0 {
-		return fmt.Errorf("M-4: sum of internal entries must equal 0 (got: %d)", internalSum)
+		violations = append(violations, newViolation("M-4", 0, internalSum,
+			"sum of internal entries must equal 0 (got: %d)", internalSum))
 	}
 
 	if prevMonth != nil {
 		// M-1: Consecutive months must chain totals
 		if prevMonth.ClosingBalance != m.OpeningBalance {
-			return fmt.Errorf("M-1: month opening balance does not equal previous month closing balance (expected: %d, got: %d)",
-				prevMonth.ClosingBalance, m.OpeningBalance)
+			violations = append(violations, newViolation("M-1", prevMonth.ClosingBalance, m.OpeningBalance,
+				"month opening balance does not equal previous month closing balance (expected: %d, got: %d)",
+				prevMonth.ClosingBalance, m.OpeningBalance))
 		}
 
 		// A-4: An account may be omitted in later months only if its last closing_balance = 0
-		for accountName, prevAccount := range prevMonth.Accounts {
+		for _, accountName := range prevMonth.GetAccountNames() {
+			prevAccount := prevMonth.Accounts[accountName]
 			if _, exists := m.Accounts[accountName]; !exists {
 				if prevAccount.ClosingBalance != 0 {
-					return fmt.Errorf("A-4: account '%s' cannot be omitted with non-zero closing balance (got: %d)",
+					violation := newViolation("A-4", 0, prevAccount.ClosingBalance,
+						"account '%s' cannot be omitted with non-zero closing balance (got: %d)",
 						accountName, prevAccount.ClosingBalance)
+					violation.Path.Account = accountName
+					violations = append(violations, violation)
 				}
 			}
 		}
 	}
 
-	return nil
+	return violations
 }
 
 // Income returns the sum of income from all accounts
@@ -101,6 +118,48 @@ func (m Month) Expenses() int {
 	})
 }
 
+// UntaggedLabel is displayed in place of the empty tag of entries without a tag.
+// Totals keep those entries under the empty tag, so they never merge with a tag named like the label.
+const UntaggedLabel = "(untagged)"
+
+// TagLabel returns the tag for display, or UntaggedLabel for the empty tag
+func TagLabel(tag string) string {
+	if tag == "" {
+		return UntaggedLabel
+	}
+	return tag
+}
+
+// TagTotals returns the sum of non-internal entries per tag across all accounts.
+// Entries without a tag are summed under the empty tag.
+func (m Month) TagTotals() map[string]int {
+	totals := make(map[string]int)
+	for _, account := range m.Accounts {
+		for _, entry := range account.Entries {
+			if !entry.Internal {
+				totals[entry.Tag] += entry.Amount
+			}
+		}
+	}
+	return totals
+}
+
+// SortTags returns the tags of the totals ordered by total, largest expenses first,
+// with untagged entries last
+func SortTags(totals map[string]int) []string {
+	tags := lo.Keys(totals)
+	sort.Slice(tags, func(i, j int) bool {
+		if (tags[i] == "") != (tags[j] == "") {
+			return tags[j] == ""
+		}
+		if totals[tags[i]] != totals[tags[j]] {
+			return totals[tags[i]] < totals[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	return tags
+}
+
 // GetAccountNames returns sorted list of account names
 func (m Month) GetAccountNames() []string {
 	names := lo.Keys(m.Accounts)
@@ -542,6 +542,98 @@ func TestMonth_Expenses(t *testing.T) {
 	}
 }
 
+func TestMonth_TagTotals(t *testing.T) {
+	tests := []struct {
+		name  string
+		month Month
+		want  map[string]int
+	}{
+		{
+			name:  "no accounts",
+			month: Month{},
+			want:  map[string]int{},
+		},
+		{
+			name: "tags summed across accounts",
+			month: Month{
+				Accounts: map[string]Account{
+					"checking": {
+						Entries: []Entry{
+							{Amount: 1000, Note: "Salary", Tag: "Income"},
+							{Amount: -200, Note: "Groceries", Tag: "Food"},
+						},
+					},
+					"card": {
+						Entries: []Entry{
+							{Amount: -50, Note: "Restaurant", Tag: "Food"},
+						},
+					},
+				},
+			},
+			want: map[string]int{"Income": 1000, "Food": -250},
+		},
+		{
+			name: "untagged entries and internal entries",
+			month: Month{
+				Accounts: map[string]Account{
+					"checking": {
+						Entries: []Entry{
+							{Amount: -30, Note: "Cash"},
+							{Amount: -500, Note: "Transfer to savings", Tag: "Transfer", Internal: true},
+						},
+					},
+					"savings": {
+						Entries: []Entry{
+							{Amount: 500, Note: "Transfer from checking", Tag: "Transfer", Internal: true},
+						},
+					},
+				},
+			},
+			want: map[string]int{"": -30},
+		},
+		{
+			name: "tags named like the untagged label",
+			month: Month{
+				Accounts: map[string]Account{
+					"checking": {
+						Entries: []Entry{
+							{Amount: -30, Note: "Cash"},
+							{Amount: -20, Note: "Snacks", Tag: "untagged"},
+							{Amount: -10, Note: "Parking", Tag: UntaggedLabel},
+						},
+					},
+				},
+			},
+			want: map[string]int{"": -30, "untagged": -20, UntaggedLabel: -10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.month.TagTotals())
+		})
+	}
+}
+
+func TestSortTags(t *testing.T) {
+	totals := map[string]int{
+		"Income": 1000,
+		"":       -500,
+		"Food":   -250,
+		"Rent":   -800,
+		"Books":  -250,
+	}
+
+	assert.Equal(t, []string{"Rent", "Books", "Food", "Income", ""}, SortTags(totals))
+	assert.Empty(t, SortTags(nil))
+}
+
+func TestTagLabel(t *testing.T) {
+	assert.Equal(t, "Food", TagLabel("Food"))
+	assert.Equal(t, "untagged", TagLabel("untagged"))
+	assert.Equal(t, UntaggedLabel, TagLabel(""))
+}
+
 func TestMonth_GetAccountNames(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -673,3 +765,51 @@ func TestMonth_Validate_ComplexScenario(t *testing.T) {
 	assert.Equal(t, -200, month.Expenses()) // -200 only
 	assert.Equal(t, []string{"checking", "savings"}, month.GetAccountNames())
 }
+
+func TestMonth_Validate_DeterministicOrder(t *testing.T) {
+	prevMonth := &Month{
+		OpeningBalance: 300,
+		ClosingBalance: 300,
+		Accounts: map[string]Account{
+			"delta": {OpeningBalance: 100, ClosingBalance: 100},
+			"alpha": {OpeningBalance: 100, ClosingBalance: 100},
+			"gamma": {OpeningBalance: 100, ClosingBalance: 100},
+		},
+	}
+
+	// Every account is broken in a different way, and two accounts are omitted
+	month := Month{
+		OpeningBalance: 250,
+		ClosingBalance: 250,
+		Accounts: map[string]Account{
+			"zulu":    {OpeningBalance: 50, ClosingBalance: 50},
+			"charlie": {OpeningBalance: 100, ClosingBalance: 150},
+			"bravo":   {OpeningBalance: 100, ClosingBalance: 50},
+		},
+	}
+
+	want := []string{
+		"account bravo: A-1: account balance calculation incorrect (opening: 100 + entries: 0 = 100, expected closing: 50)",
+		"account bravo: A-3: new account must start with opening balance 0 (got: 100)",
+		"account charlie: A-1: account balance calculation incorrect (opening: 100 + entries: 0 = 100, expected closing: 150)",
+		"account charlie: A-3: new account must start with opening balance 0 (got: 100)",
+		"account zulu: A-3: new account must start with opening balance 0 (got: 50)",
+		"M-1: month opening balance does not equal previous month closing balance (expected: 300, got: 250)",
+		"account alpha: A-4: account 'alpha' cannot be omitted with non-zero closing balance (got: 100)",
+		"account delta: A-4: account 'delta' cannot be omitted with non-zero closing balance (got: 100)",
+		"account gamma: A-4: account 'gamma' cannot be omitted with non-zero closing balance (got: 100)",
+	}
+
+	// Map iteration order is randomized, so repeat to catch order dependence
+	for i := 0; i < 20; i++ {
+		violations := month.ValidateAll(2024, 2, prevMonth)
+		got := make([]string, len(violations))
+		for j := range violations {
+			got[j] = violations[j].Error()
+		}
+		assert.Equal(t, want, got)
+
+		err := month.Validate(2024, 2, prevMonth)
+		assert.EqualError(t, err, want[0])
+	}
+}
@@ -0,0 +1,75 @@
+package v2
+
+// NetWorthMonth is the net worth of the ledger at the end of a month, with its changes and savings rate
+type NetWorthMonth struct {
+	YearMonth
+	// NetWorth is the month's closing balance across all accounts
+	NetWorth int
+	// Change is the change over the month: closing minus opening balance
+	Change int
+	// YearChange is the change since the same month of the previous year, nil if that month is missing
+	YearChange *int
+	Income     int
+	Expenses   int
+	// SavingsRate is (income + expenses) / income in percent, nil without income
+	SavingsRate *float64
+	// SavingsRate3 and SavingsRate12 are the savings rates of the summed income and expenses over the trailing
+	// 3 and 12 calendar months, including this month, computed from the months present in the ledger.
+	// A month of high income weighs more than in an average of the monthly rates.
+	SavingsRate3  *float64
+	SavingsRate12 *float64
+}
+
+// NetWorth returns the month-end net worth of every month of the ledger in chronological order
+func (l Ledger) NetWorth() []NetWorthMonth {
+	months := l.GetMonths()
+	result := make([]NetWorthMonth, 0, len(months))
+	byMonth := make(map[YearMonth]NetWorthMonth, len(months))
+
+	for _, ym := range months {
+		month := l.Years[ym.Year].Months[ym.Month]
+		nw := NetWorthMonth{
+			YearMonth:   ym,
+			NetWorth:    month.ClosingBalance,
+			Change:      month.ClosingBalance - month.OpeningBalance,
+			Income:      month.Income(),
+			Expenses:    month.Expenses(),
+			SavingsRate: SavingsRate(month.Income(), month.Expenses()),
+		}
+
+		if prev, ok := byMonth[ym.AddMonths(-12)]; ok {
+			change := nw.NetWorth - prev.NetWorth
+			nw.YearChange = &change
+		}
+
+		nw.SavingsRate3 = trailingSavingsRate(byMonth, nw, 3)
+		nw.SavingsRate12 = trailingSavingsRate(byMonth, nw, 12)
+
+		byMonth[ym] = nw
+		result = append(result, nw)
+	}
+
+	return result
+}
+
+// SavingsRate returns (income + expenses) / income in percent, or nil without income.
+// Expenses are negative, so spending everything gives 0% and spending more gives a negative rate.
+func SavingsRate(income, expenses int) *float64 {
+	if income <= 0 {
+		return nil
+	}
+	rate := float64(income+expenses) / float64(income) * 100
+	return &rate
+}
+
+// trailingSavingsRate returns the savings rate over the n calendar months ending with the given month
+func trailingSavingsRate(byMonth map[YearMonth]NetWorthMonth, current NetWorthMonth, n int) *float64 {
+	income, expenses := current.Income, current.Expenses
+	for i := 1; i < n; i++ {
+		if prev, ok := byMonth[current.AddMonths(-i)]; ok {
+			income += prev.Income
+			expenses += prev.Expenses
+		}
+	}
+	return SavingsRate(income, expenses)
+}
@@ -0,0 +1,105 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavingsRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		income   int
+		expenses int
+		want     *float64
+	}{
+		{name: "saves a quarter", income: 400, expenses: -300, want: lo.ToPtr(25.0)},
+		{name: "spends everything", income: 400, expenses: -400, want: lo.ToPtr(0.0)},
+		{name: "overspends", income: 400, expenses: -500, want: lo.ToPtr(-25.0)},
+		{name: "no income", income: 0, expenses: -100, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SavingsRate(tt.income, tt.expenses))
+		})
+	}
+}
+
+func TestLedger_NetWorth(t *testing.T) {
+	month := func(opening, income, expense int) Month {
+		return testMonth(opening, Entry{Amount: income, Note: "Salary"}, Entry{Amount: expense, Note: "Rent"})
+	}
+
+	tests := []struct {
+		name   string
+		ledger Ledger
+		want   []NetWorthMonth
+	}{
+		{
+			name: "trailing savings rates",
+			ledger: Ledger{Years: map[int]Year{
+				2024: {Months: map[int]Month{
+					1:  month(1000, 100, -50),
+					12: month(1050, 100, -100),
+				}},
+				2025: {Months: map[int]Month{
+					1: month(1050, 200, -50),
+					2: month(1200, 0, -100),
+				}},
+			}},
+			want: []NetWorthMonth{
+				{
+					YearMonth: YearMonth{2024, 1}, NetWorth: 1050, Change: 50, Income: 100, Expenses: -50,
+					SavingsRate: lo.ToPtr(50.0), SavingsRate3: lo.ToPtr(50.0), SavingsRate12: lo.ToPtr(50.0),
+				},
+				// 2024-12 trails 2024-10..2024-12 for 3 months, but 2024-01..2024-12 for 12 months
+				{
+					YearMonth: YearMonth{2024, 12}, NetWorth: 1050, Change: 0, Income: 100, Expenses: -100,
+					SavingsRate: lo.ToPtr(0.0), SavingsRate3: lo.ToPtr(0.0), SavingsRate12: lo.ToPtr(25.0),
+				},
+				{
+					YearMonth: YearMonth{2025, 1}, NetWorth: 1200, Change: 150, YearChange: lo.ToPtr(1200 - 1050), Income: 200, Expenses: -50,
+					SavingsRate: lo.ToPtr(75.0), SavingsRate3: lo.ToPtr(50.0), SavingsRate12: lo.ToPtr(50.0),
+				},
+				// No income this month: no rate, but the trailing rates include earlier income
+				{
+					YearMonth: YearMonth{2025, 2}, NetWorth: 1100, Change: -100, Income: 0, Expenses: -100,
+					SavingsRate3: SavingsRate(300, -250), SavingsRate12: SavingsRate(300, -250),
+				},
+			},
+		},
+		{
+			// The trailing rate is 50 / 400 of the summed months, not the average of 50% and 0%
+			name: "trailing rates weigh months by income",
+			ledger: Ledger{Years: map[int]Year{
+				2025: {Months: map[int]Month{
+					1: month(0, 100, -50),
+					2: month(50, 300, -300),
+				}},
+			}},
+			want: []NetWorthMonth{
+				{
+					YearMonth: YearMonth{2025, 1}, NetWorth: 50, Change: 50, Income: 100, Expenses: -50,
+					SavingsRate: lo.ToPtr(50.0), SavingsRate3: lo.ToPtr(50.0), SavingsRate12: lo.ToPtr(50.0),
+				},
+				{
+					YearMonth: YearMonth{2025, 2}, NetWorth: 50, Change: 0, Income: 300, Expenses: -300,
+					SavingsRate: lo.ToPtr(0.0), SavingsRate3: lo.ToPtr(12.5), SavingsRate12: lo.ToPtr(12.5),
+				},
+			},
+		},
+		{
+			name:   "empty ledger",
+			ledger: Ledger{},
+			want:   []NetWorthMonth{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ledger.NetWorth())
+		})
+	}
+}
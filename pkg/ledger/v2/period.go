@@ -0,0 +1,116 @@
+package v2
+
+import (
+	"fmt"
+	"time"
+)
+
+// YearMonth identifies a month of the ledger
+type YearMonth struct {
+	Year  int
+	Month int
+}
+
+// ParseYearMonth parses a month in the form "YYYY-MM"
+func ParseYearMonth(s string) (YearMonth, error) {
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return YearMonth{}, fmt.Errorf("invalid month %q: must be in YYYY-MM format", s)
+	}
+	return YearMonth{Year: t.Year(), Month: int(t.Month())}, nil
+}
+
+// String returns the month in the form "YYYY-MM"
+func (ym YearMonth) String() string {
+	return fmt.Sprintf("%04d-%02d", ym.Year, ym.Month)
+}
+
+// IsZero reports whether the month is unset
+func (ym YearMonth) IsZero() bool {
+	return ym == YearMonth{}
+}
+
+// Before reports whether the month comes before the other month
+func (ym YearMonth) Before(other YearMonth) bool {
+	return ym.index() < other.index()
+}
+
+// AddMonths returns the month n months later, or earlier for negative n
+func (ym YearMonth) AddMonths(n int) YearMonth {
+	i := ym.index() + n
+	return YearMonth{Year: i / 12, Month: i%12 + 1}
+}
+
+func (ym YearMonth) index() int {
+	return ym.Year*12 + ym.Month - 1
+}
+
+// Period is an inclusive range of months. A zero bound leaves the range open on that side.
+type Period struct {
+	From YearMonth
+	To   YearMonth
+}
+
+// Contains reports whether the month lies within the period
+func (p Period) Contains(ym YearMonth) bool {
+	if !p.From.IsZero() && ym.Before(p.From) {
+		return false
+	}
+	if !p.To.IsZero() && p.To.Before(ym) {
+		return false
+	}
+	return true
+}
+
+// GetMonths returns every month of the ledger in chronological order
+func (l Ledger) GetMonths() []YearMonth {
+	var months []YearMonth
+	for _, yearNum := range l.GetYearNumbers() {
+		for _, monthNum := range l.Years[yearNum].GetMonthNumbers() {
+			months = append(months, YearMonth{Year: yearNum, Month: monthNum})
+		}
+	}
+	return months
+}
+
+// Filter returns a ledger holding only the months within the period. The opening and closing
+// balances of each year are taken from its first and last month within the period,
+// so the year and ledger totals describe the period. The receiver is left untouched.
+func (l Ledger) Filter(p Period) Ledger {
+	filtered := Ledger{Currency: l.Currency, Budgets: l.Budgets, Years: make(map[int]Year)}
+
+	for _, ym := range l.GetMonths() {
+		if !p.Contains(ym) {
+			continue
+		}
+
+		month := l.Years[ym.Year].Months[ym.Month]
+		year, ok := filtered.Years[ym.Year]
+		if !ok {
+			year = Year{OpeningBalance: month.OpeningBalance, Months: make(map[int]Month)}
+		}
+		year.Months[ym.Month] = month
+		year.ClosingBalance = month.ClosingBalance
+		filtered.Years[ym.Year] = year
+	}
+
+	return filtered
+}
+
+// OpeningBalance returns the opening balance of the first year of the ledger
+func (l Ledger) OpeningBalance() int {
+	yearNums := l.GetYearNumbers()
+	if len(yearNums) == 0 {
+		return 0
+	}
+	return l.Years[yearNums[0]].OpeningBalance
+}
+
+// ClosingBalance returns the closing balance of the last year of the ledger
+func (l Ledger) ClosingBalance() int {
+	yearNums := l.GetYearNumbers()
+	if len(yearNums) == 0 {
+		return 0
+	}
+	return l.Years[yearNums[len(yearNums)-1]].ClosingBalance
+}
@@ -0,0 +1,145 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseYearMonth(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    YearMonth
+		wantErr bool
+	}{
+		{name: "valid", input: "2024-03", want: YearMonth{Year: 2024, Month: 3}},
+		{name: "december", input: "2025-12", want: YearMonth{Year: 2025, Month: 12}},
+		{name: "invalid month", input: "2024-13", wantErr: true},
+		{name: "full date", input: "2024-03-01", wantErr: true},
+		{name: "year only", input: "2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseYearMonth(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, got.String())
+		})
+	}
+}
+
+func TestYearMonth_AddMonths(t *testing.T) {
+	tests := []struct {
+		name string
+		ym   YearMonth
+		n    int
+		want YearMonth
+	}{
+		{name: "same year", ym: YearMonth{2025, 3}, n: 2, want: YearMonth{2025, 5}},
+		{name: "into next year", ym: YearMonth{2024, 11}, n: 3, want: YearMonth{2025, 2}},
+		{name: "back into previous year", ym: YearMonth{2025, 2}, n: -11, want: YearMonth{2024, 3}},
+		{name: "back to december", ym: YearMonth{2025, 1}, n: -1, want: YearMonth{2024, 12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ym.AddMonths(tt.n))
+		})
+	}
+}
+
+func TestPeriod_Contains(t *testing.T) {
+	period := Period{From: YearMonth{2024, 3}, To: YearMonth{2025, 2}}
+
+	assert.False(t, period.Contains(YearMonth{2024, 2}))
+	assert.True(t, period.Contains(YearMonth{2024, 3}))
+	assert.True(t, period.Contains(YearMonth{2024, 12}))
+	assert.True(t, period.Contains(YearMonth{2025, 2}))
+	assert.False(t, period.Contains(YearMonth{2025, 3}))
+
+	assert.True(t, Period{}.Contains(YearMonth{1999, 1}))
+	assert.True(t, Period{From: YearMonth{2024, 3}}.Contains(YearMonth{2099, 1}))
+	assert.True(t, Period{To: YearMonth{2024, 3}}.Contains(YearMonth{1999, 1}))
+}
+
+func TestLedger_Filter(t *testing.T) {
+	salary := Entry{Amount: 100, Note: "Salary"}
+	ledger := Ledger{
+		Currency: &Currency{Scale: 1},
+		Years: map[int]Year{
+			2024: {OpeningBalance: 100, ClosingBalance: 400, Months: map[int]Month{
+				1:  testMonth(100, salary),
+				11: testMonth(200, salary),
+				12: testMonth(300, salary),
+			}},
+			2025: {OpeningBalance: 400, ClosingBalance: 600, Months: map[int]Month{
+				1: testMonth(400, salary),
+				2: testMonth(500, salary),
+			}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ledger  Ledger
+		period  Period
+		months  []YearMonth
+		opening int
+		closing int
+	}{
+		{
+			name:    "across years",
+			ledger:  ledger,
+			period:  Period{From: YearMonth{2024, 11}, To: YearMonth{2025, 1}},
+			months:  []YearMonth{{2024, 11}, {2024, 12}, {2025, 1}},
+			opening: 200,
+			closing: 500,
+		},
+		{
+			name:    "open period",
+			ledger:  ledger,
+			months:  []YearMonth{{2024, 1}, {2024, 11}, {2024, 12}, {2025, 1}, {2025, 2}},
+			opening: 100,
+			closing: 600,
+		},
+		{
+			name:   "after the ledger",
+			ledger: ledger,
+			period: Period{From: YearMonth{2030, 1}},
+		},
+		{
+			name:   "empty ledger",
+			ledger: Ledger{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := tt.ledger.Filter(tt.period)
+			assert.Equal(t, tt.months, filtered.GetMonths())
+			assert.Equal(t, tt.opening, filtered.OpeningBalance())
+			assert.Equal(t, tt.closing, filtered.ClosingBalance())
+			assert.Equal(t, tt.ledger.Currency, filtered.Currency)
+		})
+	}
+
+	t.Run("year balances", func(t *testing.T) {
+		filtered := ledger.Filter(Period{From: YearMonth{2024, 11}, To: YearMonth{2025, 1}})
+		assert.Equal(t, 200, filtered.Years[2024].OpeningBalance)
+		assert.Equal(t, 400, filtered.Years[2024].ClosingBalance)
+		assert.Equal(t, 400, filtered.Years[2025].OpeningBalance)
+		assert.Equal(t, 500, filtered.Years[2025].ClosingBalance)
+
+		// The receiver is left untouched
+		assert.Len(t, ledger.GetMonths(), 5)
+		assert.Equal(t, 100, ledger.OpeningBalance())
+		assert.Equal(t, 600, ledger.ClosingBalance())
+	})
+}
@@ -0,0 +1,223 @@
+package v2
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+// SeriesStatus tells whether a recurring series is still going on
+type SeriesStatus string
+
+const (
+	// SeriesActive is a series whose latest occurrence is not overdue
+	SeriesActive SeriesStatus = "active"
+	// SeriesMissed is a series that missed its latest expected occurrence
+	SeriesMissed SeriesStatus = "missed"
+	// SeriesStopped is a series that missed two or more expected occurrences in a row up to the latest month of the ledger
+	SeriesStopped SeriesStatus = "stopped"
+)
+
+// DefaultMinOccurrences is the number of months an entry must appear in to be considered recurring
+const DefaultMinOccurrences = 3
+
+// RecurringOptions configures the detection of recurring entries
+type RecurringOptions struct {
+	// MinOccurrences is the number of distinct months a series must appear in, DefaultMinOccurrences if zero
+	MinOccurrences int
+	// Tolerance is the change of amount in percent of the previous amount below which
+	// the amount is considered unchanged
+	Tolerance float64
+}
+
+// Occurrence is the total of a recurring series in one month
+type Occurrence struct {
+	YearMonth
+	Amount int
+	// Date is the latest date of the month's entries, if any
+	Date string
+	// Note is the original note of the month's last entry
+	Note string
+}
+
+// AmountChange is a change of a recurring amount from one occurrence to the next
+type AmountChange struct {
+	YearMonth
+	Delta
+}
+
+// RecurringSeries is a group of entries recurring across months with the same normalized note, tag and account
+type RecurringSeries struct {
+	// Key is the normalized note the entries are grouped by
+	Key     string
+	Tag     string
+	Account string
+	// Occurrences holds one occurrence per month, in order
+	Occurrences []Occurrence
+	// Cadence is the typical number of months between occurrences
+	Cadence int
+	// Typical is the median amount
+	Typical int
+	// Changes lists the amount changes beyond the tolerance
+	Changes []AmountChange
+	// Missed lists the expected months without an occurrence between the first and the last occurrence.
+	// Months absent from the ledger are not missed.
+	Missed []YearMonth
+	// Status tells whether the series continues up to the latest month of the ledger
+	Status SeriesStatus
+}
+
+// Last returns the latest occurrence
+func (s RecurringSeries) Last() Occurrence {
+	return s.Occurrences[len(s.Occurrences)-1]
+}
+
+// Recurring detects entries recurring across months. Entries are grouped by their normalized note
+// (lowercase, without digits, punctuation and month names), tag and account, and a group recurs when
+// it appears in at least MinOccurrences months. Series are sorted by account, tag and key.
+func (l Ledger) Recurring(opts RecurringOptions) []RecurringSeries {
+	minOccurrences := opts.MinOccurrences
+	if minOccurrences <= 0 {
+		minOccurrences = DefaultMinOccurrences
+	}
+
+	months := l.GetMonths()
+	if len(months) == 0 {
+		return nil
+	}
+	latest := months[len(months)-1]
+	present := lo.SliceToMap(months, func(ym YearMonth) (YearMonth, bool) { return ym, true })
+
+	type seriesKey struct{ key, tag, account string }
+	groups := make(map[seriesKey][]Occurrence)
+	for _, ym := range months {
+		month := l.Years[ym.Year].Months[ym.Month]
+		for _, accountName := range month.GetAccountNames() {
+			byKey := make(map[seriesKey]*Occurrence)
+			var order []seriesKey
+			for _, entry := range month.Accounts[accountName].Entries {
+				k := seriesKey{key: NormalizeNote(entry.Note), tag: entry.Tag, account: accountName}
+				occurrence, ok := byKey[k]
+				if !ok {
+					occurrence = &Occurrence{YearMonth: ym}
+					byKey[k] = occurrence
+					order = append(order, k)
+				}
+				occurrence.Amount += entry.Amount
+				occurrence.Note = entry.Note
+				if entry.Date > occurrence.Date {
+					occurrence.Date = entry.Date
+				}
+			}
+			for _, k := range order {
+				groups[k] = append(groups[k], *byKey[k])
+			}
+		}
+	}
+
+	var result []RecurringSeries
+	for k, occurrences := range groups {
+		if len(occurrences) < minOccurrences {
+			continue
+		}
+
+		series := RecurringSeries{Key: k.key, Tag: k.tag, Account: k.account, Occurrences: occurrences}
+		series.Cadence = cadence(occurrences)
+		series.Typical = median(lo.Map(occurrences, func(o Occurrence, _ int) int { return o.Amount }))
+
+		for i := 1; i < len(occurrences); i++ {
+			prev, next := occurrences[i-1], occurrences[i]
+
+			change := Delta{Base: prev.Amount, Value: next.Amount}
+			if math.Abs(float64(change.Change())) > math.Abs(float64(prev.Amount))*opts.Tolerance/100 {
+				series.Changes = append(series.Changes, AmountChange{YearMonth: next.YearMonth, Delta: change})
+			}
+
+			gap := float64(next.index()-prev.index()) / float64(series.Cadence)
+			for j := 1; j < int(math.Round(gap)); j++ {
+				if expected := prev.AddMonths(j * series.Cadence); present[expected] {
+					series.Missed = append(series.Missed, expected)
+				}
+			}
+		}
+
+		overdue := 0
+		for expected := series.Last().AddMonths(series.Cadence); !latest.Before(expected); expected = expected.AddMonths(series.Cadence) {
+			if present[expected] {
+				overdue++
+			}
+		}
+
+		switch {
+		case overdue >= 2:
+			series.Status = SeriesStopped
+		case overdue == 1:
+			series.Status = SeriesMissed
+		default:
+			series.Status = SeriesActive
+		}
+
+		result = append(result, series)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		return a.Key < b.Key
+	})
+
+	return result
+}
+
+// monthNames are dropped from notes, so "Salary (January)" and "Salary (February)" group together
+var monthNames = map[string]bool{
+	"january": true, "february": true, "march": true, "april": true, "may": true, "june": true, "july": true,
+	"august": true, "september": true, "october": true, "november": true, "december": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true, "aug": true,
+	"sep": true, "sept": true, "oct": true, "nov": true, "dec": true,
+}
+
+// NormalizeNote returns the note in lowercase without digits, punctuation and month names,
+// e.g. "Netflix #1234 (March)" becomes "netflix". Notes consisting only of those are kept lowercased.
+func NormalizeNote(note string) string {
+	lower := strings.ToLower(note)
+	words := strings.FieldsFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) })
+
+	var kept []string
+	for _, word := range words {
+		if !monthNames[word] {
+			kept = append(kept, word)
+		}
+	}
+	if len(kept) == 0 {
+		return strings.Join(strings.Fields(lower), " ")
+	}
+	return strings.Join(kept, " ")
+}
+
+// cadence returns the median number of months between consecutive occurrences
+func cadence(occurrences []Occurrence) int {
+	gaps := make([]int, 0, len(occurrences)-1)
+	for i := 1; i < len(occurrences); i++ {
+		gaps = append(gaps, occurrences[i].index()-occurrences[i-1].index())
+	}
+	return max(median(gaps), 1)
+}
+
+// median returns the median of the values, the lower one of the middle two for an even count
+func median(values []int) int {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	return sorted[(len(sorted)-1)/2]
+}
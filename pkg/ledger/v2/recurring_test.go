@@ -0,0 +1,142 @@
+package v2
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeNote(t *testing.T) {
+	tests := []struct {
+		note string
+		want string
+	}{
+		{note: "Salary (January)", want: "salary"},
+		{note: "Netflix #1234 - Mar", want: "netflix"},
+		{note: "  Transfer to   Savings ", want: "transfer to savings"},
+		{note: "Café au lait", want: "café au lait"},
+		{note: "2024", want: "2024"},
+		{note: "May", want: "may"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.note, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeNote(tt.note))
+		})
+	}
+}
+
+func TestLedger_Recurring(t *testing.T) {
+	netflix := func(month, amount int) Entry {
+		return Entry{Amount: amount, Note: "Netflix", Tag: "Subscriptions", Date: fmt.Sprintf("2024-%02d-05", month)}
+	}
+	salary := func(month string) Entry { return Entry{Amount: 1000, Note: "Salary (" + month + ")", Tag: "Income"} }
+	insurance := Entry{Amount: -300, Note: "Insurance", Tag: "Insurance"}
+	cinema := Entry{Amount: -50, Note: "Cinema"}
+
+	ledger := Ledger{Years: map[int]Year{
+		2023: {Months: map[int]Month{
+			10: testMonth(0, insurance),
+		}},
+		2024: {Months: map[int]Month{
+			1: testMonth(0, netflix(1, -10), salary("January"), insurance),
+			2: testMonth(0, netflix(2, -10), salary("February"), cinema),
+			3: testMonth(0, netflix(3, -10), salary("March")),
+			4: testMonth(0, insurance),
+			5: testMonth(0, netflix(5, -12)),
+			6: testMonth(0, netflix(6, -12), cinema),
+		}},
+	}}
+
+	// Salary with the month in the note, stopped after March
+	salarySeries := RecurringSeries{Key: "salary", Tag: "Income", Account: "Checking", Occurrences: []Occurrence{
+		{YearMonth: YearMonth{2024, 1}, Amount: 1000, Note: "Salary (January)"},
+		{YearMonth: YearMonth{2024, 2}, Amount: 1000, Note: "Salary (February)"},
+		{YearMonth: YearMonth{2024, 3}, Amount: 1000, Note: "Salary (March)"},
+	}, Cadence: 1, Typical: 1000, Status: SeriesStopped}
+	// Quarterly insurance, not yet due again
+	insuranceSeries := RecurringSeries{Key: "insurance", Tag: "Insurance", Account: "Checking", Occurrences: []Occurrence{
+		{YearMonth: YearMonth{2023, 10}, Amount: -300, Note: "Insurance"},
+		{YearMonth: YearMonth{2024, 1}, Amount: -300, Note: "Insurance"},
+		{YearMonth: YearMonth{2024, 4}, Amount: -300, Note: "Insurance"},
+	}, Cadence: 3, Typical: -300, Status: SeriesActive}
+	// Monthly subscription with a price increase, missing in 2024-04
+	netflixSeries := RecurringSeries{Key: "netflix", Tag: "Subscriptions", Account: "Checking", Occurrences: []Occurrence{
+		{YearMonth: YearMonth{2024, 1}, Amount: -10, Date: "2024-01-05", Note: "Netflix"},
+		{YearMonth: YearMonth{2024, 2}, Amount: -10, Date: "2024-02-05", Note: "Netflix"},
+		{YearMonth: YearMonth{2024, 3}, Amount: -10, Date: "2024-03-05", Note: "Netflix"},
+		{YearMonth: YearMonth{2024, 5}, Amount: -12, Date: "2024-05-05", Note: "Netflix"},
+		{YearMonth: YearMonth{2024, 6}, Amount: -12, Date: "2024-06-05", Note: "Netflix"},
+	}, Cadence: 1, Typical: -10, Missed: []YearMonth{{2024, 4}}, Status: SeriesActive,
+		Changes: []AmountChange{{YearMonth: YearMonth{2024, 5}, Delta: Delta{Base: -10, Value: -12}}}}
+	// A 25% tolerance ignores the price increase
+	tolerantNetflixSeries := netflixSeries
+	tolerantNetflixSeries.Changes = nil
+	// Occasional entry, too rare to recur by default
+	cinemaSeries := RecurringSeries{Key: "cinema", Account: "Checking", Occurrences: []Occurrence{
+		{YearMonth: YearMonth{2024, 2}, Amount: -50, Note: "Cinema"},
+		{YearMonth: YearMonth{2024, 6}, Amount: -50, Note: "Cinema"},
+	}, Cadence: 4, Typical: -50, Status: SeriesActive}
+
+	gym := Entry{Amount: -30, Note: "Gym", Tag: "Sport"}
+
+	tests := []struct {
+		name   string
+		ledger Ledger
+		opts   RecurringOptions
+		want   []RecurringSeries
+	}{
+		{
+			name:   "default options",
+			ledger: ledger,
+			want:   []RecurringSeries{salarySeries, insuranceSeries, netflixSeries},
+		},
+		{
+			name:   "tolerance",
+			ledger: ledger,
+			opts:   RecurringOptions{Tolerance: 25},
+			want:   []RecurringSeries{salarySeries, insuranceSeries, tolerantNetflixSeries},
+		},
+		{
+			name:   "two occurrences",
+			ledger: ledger,
+			opts:   RecurringOptions{MinOccurrences: 2},
+			want:   []RecurringSeries{cinemaSeries, salarySeries, insuranceSeries, netflixSeries},
+		},
+		{
+			name: "missed the latest month",
+			ledger: Ledger{Years: map[int]Year{
+				2025: {Months: map[int]Month{
+					1: testMonth(0, gym),
+					2: testMonth(0, gym),
+					3: testMonth(0, gym),
+					4: testMonth(0, Entry{Amount: -5, Note: "Coffee"}),
+				}},
+			}},
+			want: []RecurringSeries{{Key: "gym", Tag: "Sport", Account: "Checking", Occurrences: []Occurrence{
+				{YearMonth: YearMonth{2025, 1}, Amount: -30, Note: "Gym"},
+				{YearMonth: YearMonth{2025, 2}, Amount: -30, Note: "Gym"},
+				{YearMonth: YearMonth{2025, 3}, Amount: -30, Note: "Gym"},
+			}, Cadence: 1, Typical: -30, Status: SeriesMissed}},
+		},
+		{
+			name:   "empty ledger",
+			ledger: Ledger{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ledger.Recurring(tt.opts))
+		})
+	}
+}
+
+func TestRecurringSeries_Last(t *testing.T) {
+	series := RecurringSeries{Occurrences: []Occurrence{
+		{YearMonth: YearMonth{2024, 1}, Date: "2024-01-05"},
+		{YearMonth: YearMonth{2024, 2}, Date: "2024-02-05"},
+	}}
+	assert.Equal(t, "2024-02-05", series.Last().Date)
+}
@@ -0,0 +1,301 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Location is a position in a ledger source file
+type Location struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// String returns the location in the editor-friendly form "file:line:col"
+func (l Location) String() string {
+	return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+}
+
+// SourceMap keeps the positions of ledger nodes in the file they were read from
+type SourceMap struct {
+	file   string
+	format Format
+	root   *sourceNode
+}
+
+// Format returns the format the ledger was read in, e.g. the format sniffed from standard input
+func (s SourceMap) Format() Format {
+	return s.format
+}
+
+// sourceNode is the position of a single YAML or JSON value and its children
+type sourceNode struct {
+	line, column       int // position of the value
+	keyLine, keyColumn int // position of the mapping key holding the value, if any
+	keys               []string
+	fields             []*sourceNode
+	items              []*sourceNode
+}
+
+// ruleFields maps rules to the field holding the offending value.
+// Rules not listed here are located at the year, month, account or entry they apply to.
+var ruleFields = map[string]string{
+	"Y-1": "opening_balance",
+	"Y-2": "opening_balance",
+	"Y-3": "closing_balance",
+	"M-1": "opening_balance",
+	"M-2": "opening_balance",
+	"M-3": "closing_balance",
+	"A-1": "closing_balance",
+	"A-2": "opening_balance",
+	"A-3": "opening_balance",
+	"E-1": "date",
+	"E-3": "date",
+	"B-2": "period",
+	"B-3": "from",
+}
+
+// Locate returns the source location of a violation. If the exact node is missing from the source,
+// e.g. an omitted account (A-4), the closest enclosing node is returned instead.
+func (s SourceMap) Locate(v Violation) (Location, bool) {
+	return s.LocateField(v.Path, ruleFields[v.Rule])
+}
+
+// LocateField returns the source location of a field, e.g. "closing_balance", of the node at the path.
+// With an empty field, or if the field is missing, the location of the node itself is returned.
+func (s SourceMap) LocateField(path Path, field string) (Location, bool) {
+	if s.root == nil {
+		return Location{}, false
+	}
+
+	if path.Budget != nil {
+		return s.locateBudget(*path.Budget, field)
+	}
+
+	node := s.root.field("years")
+	if node == nil {
+		return Location{}, false
+	}
+
+	steps := []func(*sourceNode) *sourceNode{
+		func(n *sourceNode) *sourceNode { return n.numberedField(path.Year) },
+	}
+	if path.Month != 0 {
+		steps = append(steps,
+			func(n *sourceNode) *sourceNode { return n.field("months") },
+			func(n *sourceNode) *sourceNode { return n.numberedField(path.Month) },
+		)
+	}
+	if path.Account != "" {
+		steps = append(steps,
+			func(n *sourceNode) *sourceNode { return n.field("accounts") },
+			func(n *sourceNode) *sourceNode { return n.field(path.Account) },
+		)
+	}
+	if path.Entry != nil {
+		steps = append(steps,
+			func(n *sourceNode) *sourceNode { return n.field("entries") },
+			func(n *sourceNode) *sourceNode { return n.item(*path.Entry) },
+		)
+	}
+
+	for _, step := range steps {
+		next := step(node)
+		if next == nil {
+			return s.location(node.keyLine, node.keyColumn)
+		}
+		node = next
+	}
+
+	if value := node.field(field); field != "" && value != nil {
+		return s.location(value.line, value.column)
+	}
+	if node.keyLine != 0 {
+		return s.location(node.keyLine, node.keyColumn)
+	}
+	return s.location(node.line, node.column)
+}
+
+// locateBudget returns the source location of a field of the budget with the given index
+func (s SourceMap) locateBudget(index int, field string) (Location, bool) {
+	budgets := s.root.field("budgets")
+	if budgets == nil {
+		return Location{}, false
+	}
+
+	node := budgets.item(index)
+	if node == nil {
+		return s.location(budgets.keyLine, budgets.keyColumn)
+	}
+	if value := node.field(field); field != "" && value != nil {
+		return s.location(value.line, value.column)
+	}
+	return s.location(node.line, node.column)
+}
+
+func (s SourceMap) location(line, column int) (Location, bool) {
+	if line == 0 {
+		return Location{}, false
+	}
+	return Location{File: s.file, Line: line, Column: column}, true
+}
+
+// field returns the child stored under the given mapping key
+func (n *sourceNode) field(name string) *sourceNode {
+	for i, key := range n.keys {
+		if key == name {
+			return n.fields[i]
+		}
+	}
+	return nil
+}
+
+// numberedField returns the child stored under an integer mapping key, e.g. a year or month
+func (n *sourceNode) numberedField(num int) *sourceNode {
+	for i, key := range n.keys {
+		if k, err := strconv.Atoi(key); err == nil && k == num {
+			return n.fields[i]
+		}
+	}
+	return nil
+}
+
+// item returns the sequence element with the given index
+func (n *sourceNode) item(index int) *sourceNode {
+	if index < 0 || index >= len(n.items) {
+		return nil
+	}
+	return n.items[index]
+}
+
+// newYAMLSourceMap builds a source map from YAML data
+func newYAMLSourceMap(file string, data []byte) (SourceMap, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return SourceMap{}, err
+	}
+	if len(doc.Content) == 0 {
+		return SourceMap{file: file}, nil
+	}
+
+	return SourceMap{file: file, root: yamlSourceNode(doc.Content[0])}, nil
+}
+
+func yamlSourceNode(node *yaml.Node) *sourceNode {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	result := &sourceNode{line: node.Line, column: node.Column}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := yamlSourceNode(value)
+			child.keyLine, child.keyColumn = key.Line, key.Column
+			result.keys = append(result.keys, key.Value)
+			result.fields = append(result.fields, child)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			result.items = append(result.items, yamlSourceNode(item))
+		}
+	}
+
+	return result
+}
+
+// newJSONSourceMap builds a source map from JSON data using decoder offsets
+func newJSONSourceMap(file string, data []byte) (SourceMap, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	lines := lineOffsets(data)
+
+	root, err := jsonSourceNode(dec, data, lines)
+	if err != nil {
+		return SourceMap{}, err
+	}
+
+	return SourceMap{file: file, root: root}, nil
+}
+
+func jsonSourceNode(dec *json.Decoder, data []byte, lines []int) (*sourceNode, error) {
+	result := &sourceNode{}
+	result.line, result.column = lineColumn(lines, jsonValueStart(data, dec.InputOffset()))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			keyLine, keyColumn := lineColumn(lines, jsonValueStart(data, dec.InputOffset()))
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			child, err := jsonSourceNode(dec, data, lines)
+			if err != nil {
+				return nil, err
+			}
+			child.keyLine, child.keyColumn = keyLine, keyColumn
+			result.keys = append(result.keys, fmt.Sprint(key))
+			result.fields = append(result.fields, child)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	case json.Delim('['):
+		for dec.More() {
+			child, err := jsonSourceNode(dec, data, lines)
+			if err != nil {
+				return nil, err
+			}
+			result.items = append(result.items, child)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// jsonValueStart skips whitespace and separators following the decoder offset to find the next token
+func jsonValueStart(data []byte, offset int64) int {
+	i := int(offset)
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// lineOffsets returns the byte offset at which each line starts
+func lineOffsets(data []byte) []int {
+	offsets := []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// lineColumn converts a byte offset to a 1-based line and column
+func lineColumn(lines []int, offset int) (int, int) {
+	line := sort.Search(len(lines), func(i int) bool { return lines[i] > offset }) - 1
+	return line + 1, offset - lines[line] + 1
+}
@@ -0,0 +1,228 @@
+package v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceMap_Locate(t *testing.T) {
+	tempDir := t.TempDir()
+
+	yamlContent := `years:
+  2025:
+    opening_balance: 1000
+    closing_balance: 1050
+    months:
+      1:
+        opening_balance: 1000
+        closing_balance: 1050
+        accounts:
+          Checking:
+            opening_balance: 600
+            closing_balance: 650
+            entries:
+              - amount: 50
+                note: "Salary"
+                date: "2025-02-28"
+              - {amount: -20, note: "Fee", date: "2025-01-30"}
+          Savings:
+            opening_balance: 400
+            closing_balance: 400
+            entries: []
+`
+
+	jsonContent := `{
+  "years": {
+    "2025": {
+      "opening_balance": 1000,
+      "closing_balance": 1050,
+      "months": {
+        "1": {
+          "opening_balance": 1000,
+          "closing_balance": 1050,
+          "accounts": {
+            "Checking": {
+              "opening_balance": 600,
+              "closing_balance": 650,
+              "entries": [
+                {"amount": 50, "note": "Salary", "date": "2025-02-28"},
+                {"amount": -20, "note": "Fee", "date": "2025-01-30"}
+              ]
+            },
+            "Savings": {
+              "opening_balance": 400,
+              "closing_balance": 400,
+              "entries": []
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+	entry := 0
+
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		violation Violation
+		wantLine  int
+		wantCol   int
+	}{
+		{
+			name:      "YAML entry date",
+			file:      "ledger.yaml",
+			content:   yamlContent,
+			violation: Violation{Rule: "E-1", Path: Path{Year: 2025, Month: 1, Account: "Checking", Entry: &entry}},
+			wantLine:  16,
+			wantCol:   23,
+		},
+		{
+			name:      "YAML account closing balance",
+			file:      "ledger.yaml",
+			content:   yamlContent,
+			violation: Violation{Rule: "A-1", Path: Path{Year: 2025, Month: 1, Account: "Checking"}},
+			wantLine:  12,
+			wantCol:   30,
+		},
+		{
+			name:      "YAML month key",
+			file:      "ledger.yaml",
+			content:   yamlContent,
+			violation: Violation{Rule: "M-4", Path: Path{Year: 2025, Month: 1}},
+			wantLine:  6,
+			wantCol:   7,
+		},
+		{
+			name:      "YAML missing account falls back to accounts",
+			file:      "ledger.yaml",
+			content:   yamlContent,
+			violation: Violation{Rule: "A-4", Path: Path{Year: 2025, Month: 1, Account: "Credit"}},
+			wantLine:  9,
+			wantCol:   9,
+		},
+		{
+			name:      "JSON entry date",
+			file:      "ledger.json",
+			content:   jsonContent,
+			violation: Violation{Rule: "E-1", Path: Path{Year: 2025, Month: 1, Account: "Checking", Entry: &entry}},
+			wantLine:  15,
+			wantCol:   58,
+		},
+		{
+			name:      "JSON year opening balance",
+			file:      "ledger.json",
+			content:   jsonContent,
+			violation: Violation{Rule: "Y-2", Path: Path{Year: 2025}},
+			wantLine:  4,
+			wantCol:   26,
+		},
+		{
+			name:      "JSON year key",
+			file:      "ledger.json",
+			content:   jsonContent,
+			violation: Violation{Rule: "Y-4", Path: Path{Year: 2025}},
+			wantLine:  3,
+			wantCol:   5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			_, source, err := ReadLedgerWithSource(path)
+			require.NoError(t, err)
+
+			loc, ok := source.Locate(tt.violation)
+			require.True(t, ok)
+			assert.Equal(t, Location{File: path, Line: tt.wantLine, Column: tt.wantCol}, loc)
+		})
+	}
+}
+
+func TestSourceMap_LocateValidationViolations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.yaml")
+	content := `years:
+  2025:
+    opening_balance: 100
+    closing_balance: 100
+    months:
+      1:
+        opening_balance: 100
+        closing_balance: 100
+        accounts:
+          Checking:
+            opening_balance: 100
+            closing_balance: 100
+            entries:
+              - amount: 10
+                note: "Refund"
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	ledger, source, err := ReadLedgerWithSource(path)
+	require.NoError(t, err)
+
+	violations := ledger.ValidateAll()
+	require.NotEmpty(t, violations)
+
+	loc, ok := source.Locate(violations[0])
+	require.True(t, ok)
+	assert.Equal(t, "A-1", violations[0].Rule)
+	assert.Equal(t, path+":12:30", loc.String())
+}
+
+func TestSourceMap_LocateBudgetViolations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.yaml")
+	content := `budgets:
+  - tag: Food
+    amount: 400
+  - tag: Travel
+    amount: 2000
+    period: year
+    from: 2025-03
+years:
+  2025:
+    opening_balance: 0
+    closing_balance: 0
+    months:
+      1:
+        opening_balance: 0
+        closing_balance: 0
+        accounts:
+          Checking:
+            opening_balance: 0
+            closing_balance: 0
+            entries: []
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	ledger, source, err := ReadLedgerWithSource(path)
+	require.NoError(t, err)
+
+	violations := ledger.ValidateAll()
+	require.Len(t, violations, 1)
+	assert.Equal(t, "B-3", violations[0].Rule)
+
+	loc, ok := source.Locate(violations[0])
+	require.True(t, ok)
+	assert.Equal(t, path+":7:11", loc.String())
+
+	loc, ok = source.LocateField(Path{Budget: lo.ToPtr(0)}, "")
+	require.True(t, ok)
+	assert.Equal(t, path+":2:5", loc.String())
+}
+
+func TestSourceMap_Empty(t *testing.T) {
+	_, ok := SourceMap{}.Locate(Violation{Rule: "Y-0"})
+	assert.False(t, ok)
+}
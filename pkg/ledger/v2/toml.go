@@ -0,0 +1,98 @@
+package v2
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TOML keys are always strings, so years and months are encoded through mirror types
+// keyed by the decimal representation of their numbers
+
+type tomlLedger struct {
+	Currency *Currency           `toml:"currency,omitempty"`
+	Budgets  []Budget            `toml:"budgets,omitempty"`
+	Years    map[string]tomlYear `toml:"years"`
+}
+
+type tomlYear struct {
+	OpeningBalance int                  `toml:"opening_balance"`
+	ClosingBalance int                  `toml:"closing_balance"`
+	Months         map[string]tomlMonth `toml:"months"`
+}
+
+type tomlMonth struct {
+	OpeningBalance int                `toml:"opening_balance"`
+	ClosingBalance int                `toml:"closing_balance"`
+	Accounts       map[string]Account `toml:"accounts"`
+}
+
+// marshalTOML encodes a ledger as TOML
+func marshalTOML(ledger Ledger) ([]byte, error) {
+	doc := tomlLedger{Currency: ledger.Currency, Budgets: ledger.Budgets, Years: make(map[string]tomlYear, len(ledger.Years))}
+	for yearNum, year := range ledger.Years {
+		months := make(map[string]tomlMonth, len(year.Months))
+		for monthNum, month := range year.Months {
+			months[strconv.Itoa(monthNum)] = tomlMonth{
+				OpeningBalance: month.OpeningBalance,
+				ClosingBalance: month.ClosingBalance,
+				Accounts:       month.Accounts,
+			}
+		}
+
+		doc.Years[strconv.Itoa(yearNum)] = tomlYear{
+			OpeningBalance: year.OpeningBalance,
+			ClosingBalance: year.ClosingBalance,
+			Months:         months,
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalTOML decodes a TOML document into a ledger
+func unmarshalTOML(data []byte, ledger *Ledger) error {
+	var doc tomlLedger
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	years := make(map[int]Year, len(doc.Years))
+	for yearKey, year := range doc.Years {
+		yearNum, err := strconv.Atoi(yearKey)
+		if err != nil {
+			return fmt.Errorf("invalid year key %q: must be an integer", yearKey)
+		}
+
+		months := make(map[int]Month, len(year.Months))
+		for monthKey, month := range year.Months {
+			monthNum, err := strconv.Atoi(monthKey)
+			if err != nil {
+				return fmt.Errorf("year %d: invalid month key %q: must be an integer", yearNum, monthKey)
+			}
+
+			months[monthNum] = Month{
+				OpeningBalance: month.OpeningBalance,
+				ClosingBalance: month.ClosingBalance,
+				Accounts:       month.Accounts,
+			}
+		}
+
+		years[yearNum] = Year{
+			OpeningBalance: year.OpeningBalance,
+			ClosingBalance: year.ClosingBalance,
+			Months:         months,
+		}
+	}
+
+	ledger.Currency = doc.Currency
+	ledger.Budgets = doc.Budgets
+	ledger.Years = years
+	return nil
+}
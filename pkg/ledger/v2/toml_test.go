@@ -0,0 +1,128 @@
+package v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOML_RoundTrip(t *testing.T) {
+	ledger := Ledger{
+		Budgets: []Budget{
+			{Tag: "Food", Amount: 400},
+			{Tag: "Travel", Amount: 2000, Period: BudgetYearly, From: "2025-01"},
+		},
+		Years: map[int]Year{
+			2024: {
+				OpeningBalance: 1000,
+				ClosingBalance: 1000,
+				Months: map[int]Month{
+					12: {
+						OpeningBalance: 1000,
+						ClosingBalance: 1000,
+						Accounts: map[string]Account{
+							"Checking": {
+								OpeningBalance: 1000,
+								ClosingBalance: 1000,
+								Entries: []Entry{
+									{Amount: -30, Internal: true, Note: "Transfer to Savings", Date: "2024-12-30", Tag: "Transfer"},
+									{Amount: 30, Internal: true, Note: "Transfer back", Date: "2024-12-31", Tag: "Transfer"},
+								},
+							},
+						},
+					},
+				},
+			},
+			2025: {
+				OpeningBalance: 1000,
+				ClosingBalance: 1050,
+				Months: map[int]Month{
+					1: {
+						OpeningBalance: 1000,
+						ClosingBalance: 1050,
+						Accounts: map[string]Account{
+							"Checking": {
+								OpeningBalance: 1000,
+								ClosingBalance: 1050,
+								Entries: []Entry{
+									{Amount: 50, Note: "Salary (January)", Date: "2025-01-28", Tag: "Income"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "ledger.toml")
+	require.NoError(t, WriteLedger(ledger, path))
+
+	read, err := ReadLedger(path)
+	require.NoError(t, err)
+	assert.Equal(t, ledger, read)
+	assert.NoError(t, read.Validate())
+}
+
+func TestTOML_Read(t *testing.T) {
+	content := `[years.2025]
+opening_balance = 1000
+closing_balance = 1050
+
+[years.2025.months.1]
+opening_balance = 1000
+closing_balance = 1050
+
+[years.2025.months.1.accounts.Checking]
+opening_balance = 1000
+closing_balance = 1050
+
+[[years.2025.months.1.accounts.Checking.entries]]
+amount = 50
+note = "Salary"
+date = "2025-01-28"
+tag = "Income"
+`
+	path := filepath.Join(t.TempDir(), "ledger.toml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	ledger, err := ReadLedger(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1050, ledger.Years[2025].Months[1].ClosingBalance)
+	assert.Equal(t, "Salary", ledger.Years[2025].Months[1].Accounts["Checking"].Entries[0].Note)
+	assert.NoError(t, ledger.Validate())
+}
+
+func TestTOML_InvalidKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "year key",
+			content: "[years.twenty]\nopening_balance = 0\n",
+			errMsg:  `invalid year key "twenty": must be an integer`,
+		},
+		{
+			name:    "month key",
+			content: "[years.2025.months.jan]\nopening_balance = 0\n",
+			errMsg:  `year 2025: invalid month key "jan": must be an integer`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ledger.toml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			_, err := ReadLedger(path)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrParse)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package v2
+
+import "sort"
+
+// LedgerEntry is an entry together with the month and account it is recorded in
+type LedgerEntry struct {
+	YearMonth
+	Account string
+	Entry
+}
+
+// GetDate returns the entry date, or the month in YYYY-MM format for entries without a date
+func (e LedgerEntry) GetDate() string {
+	if e.Date != "" {
+		return e.Date
+	}
+	return e.YearMonth.String()
+}
+
+// GetTag returns the entry tag for display, or UntaggedLabel for entries without a tag
+func (e LedgerEntry) GetTag() string {
+	return TagLabel(e.Tag)
+}
+
+// Entries returns every entry of the ledger in chronological order of months,
+// then by account name, then in the order recorded
+func (l Ledger) Entries() []LedgerEntry {
+	var result []LedgerEntry
+	for _, ym := range l.GetMonths() {
+		month := l.Years[ym.Year].Months[ym.Month]
+		for _, accountName := range month.GetAccountNames() {
+			for _, entry := range month.Accounts[accountName].Entries {
+				result = append(result, LedgerEntry{YearMonth: ym, Account: accountName, Entry: entry})
+			}
+		}
+	}
+	return result
+}
+
+// LargestEntries returns up to n largest expenses, most negative first, and up to n largest incomes,
+// most positive first. Entries of equal amounts keep their order. A non-positive n returns all of them.
+func LargestEntries(entries []LedgerEntry, n int) (expenses, incomes []LedgerEntry) {
+	for _, entry := range entries {
+		switch {
+		case entry.Amount < 0:
+			expenses = append(expenses, entry)
+		case entry.Amount > 0:
+			incomes = append(incomes, entry)
+		}
+	}
+
+	sort.SliceStable(expenses, func(i, j int) bool { return expenses[i].Amount < expenses[j].Amount })
+	sort.SliceStable(incomes, func(i, j int) bool { return incomes[i].Amount > incomes[j].Amount })
+
+	if n > 0 {
+		expenses = expenses[:min(n, len(expenses))]
+		incomes = incomes[:min(n, len(incomes))]
+	}
+	return expenses, incomes
+}
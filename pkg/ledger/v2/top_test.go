@@ -0,0 +1,57 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger_Entries(t *testing.T) {
+	ledger := Ledger{Years: map[int]Year{
+		2025: {Months: map[int]Month{
+			2: {Accounts: map[string]Account{
+				"Savings":  {Entries: []Entry{{Amount: 5, Note: "Interest"}}},
+				"Checking": {Entries: []Entry{{Amount: -20, Note: "Food", Date: "2025-02-03"}, {Amount: -10, Note: "Cinema"}}},
+			}},
+		}},
+		2024: {Months: map[int]Month{
+			12: {Accounts: map[string]Account{"Checking": {Entries: []Entry{{Amount: 100, Note: "Salary", Tag: "Income"}}}}},
+		}},
+	}}
+
+	entries := ledger.Entries()
+
+	assert.Equal(t, []string{"Salary", "Food", "Cinema", "Interest"}, lo.Map(entries, func(e LedgerEntry, _ int) string { return e.Note }))
+	assert.Equal(t, LedgerEntry{YearMonth: YearMonth{2025, 2}, Account: "Checking", Entry: Entry{Amount: -20, Note: "Food", Date: "2025-02-03"}}, entries[1])
+	assert.Equal(t, "2025-02-03", entries[1].GetDate())
+	assert.Equal(t, "2025-02", entries[2].GetDate())
+	assert.Equal(t, "Income", entries[0].GetTag())
+	assert.Equal(t, UntaggedLabel, entries[2].GetTag())
+}
+
+func TestLargestEntries(t *testing.T) {
+	entry := func(amount int, note string) LedgerEntry {
+		return LedgerEntry{Entry: Entry{Amount: amount, Note: note}}
+	}
+	entries := []LedgerEntry{
+		entry(-50, "Food"),
+		entry(1000, "Salary"),
+		entry(-700, "Rent"),
+		entry(0, "Nothing"),
+		entry(-50, "Fuel"),
+		entry(30, "Refund"),
+		entry(-5, "Coffee"),
+	}
+	notes := func(entries []LedgerEntry) []string {
+		return lo.Map(entries, func(e LedgerEntry, _ int) string { return e.Note })
+	}
+
+	expenses, incomes := LargestEntries(entries, 3)
+	assert.Equal(t, []string{"Rent", "Food", "Fuel"}, notes(expenses))
+	assert.Equal(t, []string{"Salary", "Refund"}, notes(incomes))
+
+	expenses, incomes = LargestEntries(entries, 0)
+	assert.Equal(t, []string{"Rent", "Food", "Fuel", "Coffee"}, notes(expenses))
+	assert.Len(t, incomes, 2)
+}
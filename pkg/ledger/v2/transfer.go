@@ -0,0 +1,131 @@
+package v2
+
+import "sort"
+
+// Transfer is money moved between two accounts within a month, paired from two internal entries
+type Transfer struct {
+	YearMonth
+	From   string
+	To     string
+	Amount int
+	// Date is the date of the outgoing entry, or of the incoming one if the outgoing has none
+	Date string
+	// Note is the note of the outgoing entry, or of the incoming one if the outgoing has none
+	Note string
+}
+
+// Transfers pairs the internal entries of every month into transfers between accounts.
+// An outgoing entry is paired with an incoming entry of the same amount in another account
+// of the same month. Closest candidates are paired first: the fewest days apart, then with
+// the same normalized note, then in the order recorded. Internal entries left without a
+// counterpart are returned as unpaired.
+func (l Ledger) Transfers() (transfers []Transfer, unpaired []LedgerEntry) {
+	byMonth := make(map[YearMonth][]LedgerEntry)
+	var months []YearMonth
+	for _, entry := range l.Entries() {
+		if !entry.Internal {
+			continue
+		}
+		if _, ok := byMonth[entry.YearMonth]; !ok {
+			months = append(months, entry.YearMonth)
+		}
+		byMonth[entry.YearMonth] = append(byMonth[entry.YearMonth], entry)
+	}
+
+	for _, ym := range months {
+		t, u := pairTransfers(byMonth[ym])
+		transfers = append(transfers, t...)
+		unpaired = append(unpaired, u...)
+	}
+
+	return transfers, unpaired
+}
+
+// undatedDistance is the distance in days assumed when either entry of a pair has no date,
+// farther than any two dates within a month
+const undatedDistance = 31
+
+// transferCandidate is a possible pairing of an outgoing and an incoming entry
+type transferCandidate struct {
+	out, in  int
+	distance int
+	sameNote bool
+}
+
+// pairTransfers pairs the internal entries of a single month, closest candidates first,
+// so a loose match never takes the exact counterpart of another transfer.
+// Transfers are returned in the order of their outgoing entries.
+func pairTransfers(entries []LedgerEntry) (transfers []Transfer, unpaired []LedgerEntry) {
+	var candidates []transferCandidate
+	for i, out := range entries {
+		if out.Amount >= 0 {
+			continue
+		}
+		note := NormalizeNote(out.Note)
+		for j, in := range entries {
+			if in.Amount != -out.Amount || in.Account == out.Account {
+				continue
+			}
+			candidates = append(candidates, transferCandidate{
+				out:      i,
+				in:       j,
+				distance: dateDistance(out, in),
+				sameNote: note != "" && note == NormalizeNote(in.Note),
+			})
+		}
+	}
+
+	// Stable sort keeps the order recorded among equally close candidates
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if candidates[a].distance != candidates[b].distance {
+			return candidates[a].distance < candidates[b].distance
+		}
+		return candidates[a].sameNote && !candidates[b].sameNote
+	})
+
+	paired := make([]bool, len(entries))
+	counterpart := make(map[int]int)
+	for _, c := range candidates {
+		if paired[c.out] || paired[c.in] {
+			continue
+		}
+		paired[c.out], paired[c.in] = true, true
+		counterpart[c.out] = c.in
+	}
+
+	for i, out := range entries {
+		j, ok := counterpart[i]
+		if !ok {
+			continue
+		}
+
+		in := entries[j]
+		transfer := Transfer{YearMonth: out.YearMonth, From: out.Account, To: in.Account, Amount: in.Amount, Date: out.Date, Note: out.Note}
+		if transfer.Date == "" {
+			transfer.Date = in.Date
+		}
+		if transfer.Note == "" {
+			transfer.Note = in.Note
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	for i, entry := range entries {
+		if !paired[i] {
+			unpaired = append(unpaired, entry)
+		}
+	}
+
+	return transfers, unpaired
+}
+
+// dateDistance returns the number of days between the dates of two entries,
+// or undatedDistance if either has no valid date
+func dateDistance(a, b LedgerEntry) int {
+	dateA, okA, _ := a.ParseDate()
+	dateB, okB, _ := b.ParseDate()
+	if !okA || !okB {
+		return undatedDistance
+	}
+	return Abs(int(dateA.Sub(dateB).Hours() / 24))
+}
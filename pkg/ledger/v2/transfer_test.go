@@ -0,0 +1,74 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger_Transfers(t *testing.T) {
+	ledger := Ledger{Years: map[int]Year{
+		2025: {Months: map[int]Month{
+			1: {Accounts: map[string]Account{
+				"Checking": {Entries: []Entry{
+					{Amount: -100, Internal: true, Note: "To savings", Date: "2025-01-20"},
+					{Amount: -100, Internal: true, Note: "Card payment", Date: "2025-01-25"},
+					{Amount: -40, Internal: true, Note: "Lost"},
+					{Amount: -500, Note: "Rent"},
+				}},
+				"Credit card": {Entries: []Entry{{Amount: 100, Internal: true, Note: "Card payment", Date: "2025-01-26"}}},
+				"Savings":     {Entries: []Entry{{Amount: 100, Internal: true, Note: "From checking", Date: "2025-01-20"}}},
+			}},
+			2: {Accounts: map[string]Account{
+				"Checking": {Entries: []Entry{{Amount: 30, Internal: true, Note: "Refund"}}},
+				"Savings":  {Entries: []Entry{{Amount: -100, Internal: true}}},
+				"Broker":   {Entries: []Entry{{Amount: 100, Internal: true, Note: "Deposit"}}},
+			}},
+		}},
+	}}
+
+	transfers, unpaired := ledger.Transfers()
+
+	assert.Equal(t, []Transfer{
+		{YearMonth: YearMonth{2025, 1}, From: "Checking", To: "Savings", Amount: 100, Date: "2025-01-20", Note: "To savings"},
+		{YearMonth: YearMonth{2025, 1}, From: "Checking", To: "Credit card", Amount: 100, Date: "2025-01-25", Note: "Card payment"},
+		{YearMonth: YearMonth{2025, 2}, From: "Savings", To: "Broker", Amount: 100, Note: "Deposit"},
+	}, transfers)
+	assert.Equal(t, []string{"Lost", "Refund"}, lo.Map(unpaired, func(e LedgerEntry, _ int) string { return e.Note }))
+	assert.Equal(t, YearMonth{2025, 2}, unpaired[1].YearMonth)
+}
+
+func TestPairTransfers_ClosestFirst(t *testing.T) {
+	entry := func(account string, amount int, note, date string) LedgerEntry {
+		return LedgerEntry{Account: account, Entry: Entry{Amount: amount, Internal: true, Note: note, Date: date}}
+	}
+	// The card payment comes first but its counterpart is booked a day later,
+	// while the savings deposit matches the investment withdrawal exactly
+	entries := []LedgerEntry{
+		entry("Checking", -100, "Card payment", "2025-01-04"),
+		entry("Credit card", 100, "Card payment", "2025-01-05"),
+		entry("Investments", -100, "Transfer to savings", "2025-01-04"),
+		entry("Savings", 100, "Transfer to savings", "2025-01-04"),
+	}
+
+	transfers, unpaired := pairTransfers(entries)
+
+	assert.Equal(t, []Transfer{
+		{From: "Checking", To: "Credit card", Amount: 100, Date: "2025-01-04", Note: "Card payment"},
+		{From: "Investments", To: "Savings", Amount: 100, Date: "2025-01-04", Note: "Transfer to savings"},
+	}, transfers)
+	assert.Empty(t, unpaired)
+}
+
+func TestPairTransfers_SameAccount(t *testing.T) {
+	entries := []LedgerEntry{
+		{Account: "Checking", Entry: Entry{Amount: -10, Internal: true}},
+		{Account: "Checking", Entry: Entry{Amount: 10, Internal: true}},
+	}
+
+	transfers, unpaired := pairTransfers(entries)
+
+	assert.Empty(t, transfers)
+	assert.Equal(t, entries, unpaired)
+}
@@ -0,0 +1,111 @@
+package v2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity describes how serious a violation is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Path locates a violation within the ledger. Zero values mean the level does not apply,
+// e.g. a month-level violation has no Account and no Entry.
+type Path struct {
+	Year    int    `json:"year,omitempty"`
+	Month   int    `json:"month,omitempty"`
+	Account string `json:"account,omitempty"`
+	Entry   *int   `json:"entry,omitempty"`
+	// Budget is the index of the budget a B-* violation applies to; budgets are not located within years
+	Budget *int `json:"budget,omitempty"`
+}
+
+// String returns the path in the form "year 2024: month 7: account Savings: entry 3", or "budget 2"
+func (p Path) String() string {
+	var parts []string
+	if p.Budget != nil {
+		parts = append(parts, fmt.Sprintf("budget %d", *p.Budget))
+	}
+	if p.Year != 0 {
+		parts = append(parts, fmt.Sprintf("year %d", p.Year))
+	}
+	if p.Month != 0 {
+		parts = append(parts, fmt.Sprintf("month %d", p.Month))
+	}
+	if p.Account != "" {
+		parts = append(parts, fmt.Sprintf("account %s", p.Account))
+	}
+	if p.Entry != nil {
+		parts = append(parts, fmt.Sprintf("entry %d", *p.Entry))
+	}
+
+	return strings.Join(parts, ": ")
+}
+
+// Violation is a single OLF v2.0 rule violation found during validation.
+// Errors returned by the Validate methods are *Violation and can be inspected with errors.As.
+type Violation struct {
+	Rule     string   `json:"rule"`     // rule ID from the specification, e.g. "A-1"
+	Path     Path     `json:"path"`     // where in the ledger the violation was found
+	Severity Severity `json:"severity"` // how serious the violation is
+	Message  string   `json:"message"`  // human-readable description, including the rule ID
+	Expected int      `json:"expected"` // expected value for rules comparing amounts or numbers, otherwise 0
+	Actual   int      `json:"actual"`   // actual value for rules comparing amounts or numbers, otherwise 0
+}
+
+// Rules describes every rule of the OLF v2.0 specification by its ID
+var Rules = map[string]string{
+	"Y-0": "Year key must be a positive integer",
+	"Y-1": "Consecutive years must chain totals: prev.closing_balance = next.opening_balance",
+	"Y-2": "A year's opening_balance equals the first month's opening_balance",
+	"Y-3": "A year's closing_balance equals the last month's closing_balance",
+	"Y-4": "A Year must contain at least one Month entry",
+	"M-0": "Month key must be between 1 and 12 (inclusive)",
+	"M-1": "Consecutive months must chain totals: prev.closing_balance = next.opening_balance",
+	"M-2": "A month's opening_balance equals the sum of all account opening_balance values",
+	"M-3": "A month's closing_balance equals the sum of all account closing_balance values",
+	"M-4": "Within each month, the sum of internal entry amounts must equal 0",
+	"M-5": "A Month must contain at least one Account entry",
+	"A-1": "For every account: opening_balance + sum(entry.amount) = closing_balance",
+	"A-2": "If an account exists in consecutive months, prev.closing_balance = next.opening_balance",
+	"A-3": "A new account must start with opening_balance = 0",
+	"A-4": "An account may be omitted in later months only if its last closing_balance = 0",
+	"E-1": "If an entry has a date, it must lie within the year and month of its parent Month",
+	"E-2": "Every Entry must include both amount and non-empty note fields",
+	"E-3": "If date is present, it must follow the ISO-8601 YYYY-MM-DD format",
+	"B-1": "Every Budget must include a non-empty tag and a positive amount",
+	"B-2": "A budget period must be either month or year",
+	"B-3": "If from is present, it must follow the YYYY-MM format, and yearly budgets must start in January",
+	"B-4": "Budgets of the same tag and period must not start in the same month",
+}
+
+// newViolation creates an error-level violation of the given rule
+func newViolation(rule string, expected, actual int, format string, args ...any) Violation {
+	return Violation{
+		Rule:     rule,
+		Severity: SeverityError,
+		Message:  rule + ": " + fmt.Sprintf(format, args...),
+		Expected: expected,
+		Actual:   actual,
+	}
+}
+
+// Error returns the violation prefixed with its path, e.g. "year 2024: month 7: account Savings: A-1: ..."
+func (v *Violation) Error() string {
+	if path := v.Path.String(); path != "" {
+		return path + ": " + v.Message
+	}
+	return v.Message
+}
+
+// firstViolation returns the first violation as an error, or nil if there are none
+func firstViolation(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &violations[0]
+}
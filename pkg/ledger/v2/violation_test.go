@@ -0,0 +1,88 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViolation_Error(t *testing.T) {
+	entry := 3
+
+	tests := []struct {
+		name      string
+		violation Violation
+		want      string
+	}{
+		{
+			name:      "no path",
+			violation: newViolation("Y-0", 0, -1, "year number must be greater than 0 (got: %d)", -1),
+			want:      "Y-0: year number must be greater than 0 (got: -1)",
+		},
+		{
+			name: "full path",
+			violation: Violation{
+				Rule:    "E-1",
+				Path:    Path{Year: 2024, Month: 7, Account: "Savings", Entry: &entry},
+				Message: "E-1: entry date month does not match expected month (expected: 7, got: 8)",
+			},
+			want: "year 2024: month 7: account Savings: entry 3: E-1: entry date month does not match expected month (expected: 7, got: 8)",
+		},
+		{
+			name: "month level",
+			violation: Violation{
+				Rule:    "M-4",
+				Path:    Path{Year: 2024, Month: 7},
+				Message: "M-4: sum of internal entries must equal 0 (got: 10)",
+			},
+			want: "year 2024: month 7: M-4: sum of internal entries must equal 0 (got: 10)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.violation.Error())
+		})
+	}
+}
+
+func TestViolation_ErrorsAs(t *testing.T) {
+	account := Account{
+		OpeningBalance: 100,
+		ClosingBalance: 150,
+		Entries: []Entry{
+			{Amount: 20, Note: "Interest"},
+		},
+	}
+
+	err := fmt.Errorf("validation failed: %w", account.Validate(2024, 1, nil, false))
+
+	var violation *Violation
+	require.True(t, errors.As(err, &violation))
+	assert.Equal(t, "A-1", violation.Rule)
+	assert.Equal(t, SeverityError, violation.Severity)
+	assert.Equal(t, 120, violation.Expected)
+	assert.Equal(t, 150, violation.Actual)
+}
+
+func TestViolation_EntryPath(t *testing.T) {
+	account := Account{
+		OpeningBalance: 0,
+		ClosingBalance: 100,
+		Entries: []Entry{
+			{Amount: 50, Note: "Salary", Date: "2024-01-15"},
+			{Amount: 50, Note: "Bonus", Date: "2024-02-15"},
+		},
+	}
+
+	violations := account.ValidateAll(2024, 1, nil, false)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "E-1", violations[0].Rule)
+	require.NotNil(t, violations[0].Path.Entry)
+	assert.Equal(t, 1, *violations[0].Path.Entry)
+	assert.Equal(t, 1, violations[0].Expected)
+	assert.Equal(t, 2, violations[0].Actual)
+}
@@ -0,0 +1,181 @@
+package v2
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// updateYAML applies the ledger onto existing YAML source and returns the updated document.
+// Only changed values are rewritten: comments, key order and the style of untouched nodes,
+// e.g. flow-style entries, are preserved.
+func updateYAML(source []byte, ledger Ledger) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(source, &doc); err != nil {
+		return nil, err
+	}
+
+	var updated yaml.Node
+	if err := updated.Encode(ledger); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&updated}}
+	} else {
+		mergeYAMLNode(doc.Content[0], &updated)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(detectYAMLIndent(source))
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mergeYAMLNode updates dst in place so that it represents the same value as src,
+// keeping the comments and styles of dst wherever possible
+func mergeYAMLNode(dst, src *yaml.Node) {
+	if dst.Kind != src.Kind {
+		// A missing value decodes to the zero value, so an empty collection replacing null is no change
+		if isZeroYAMLNode(dst) && isZeroYAMLNode(src) {
+			return
+		}
+
+		headComment, lineComment, footComment := dst.HeadComment, dst.LineComment, dst.FootComment
+		*dst = *src
+		dst.HeadComment, dst.LineComment, dst.FootComment = headComment, lineComment, footComment
+		return
+	}
+
+	switch dst.Kind {
+	case yaml.ScalarNode:
+		if !sameYAMLScalar(dst, src) {
+			if dst.ShortTag() != src.ShortTag() {
+				dst.Style = src.Style
+			}
+			dst.Value = src.Value
+			dst.Tag = src.Tag
+		}
+	case yaml.MappingNode:
+		mergeYAMLMapping(dst, src)
+	case yaml.SequenceNode:
+		for i, item := range src.Content {
+			if i < len(dst.Content) {
+				mergeYAMLNode(dst.Content[i], item)
+				continue
+			}
+
+			// New items follow the style of their siblings, e.g. flow-style entries
+			if len(dst.Content) > 0 {
+				item.Style = dst.Content[len(dst.Content)-1].Style
+			}
+			dst.Content = append(dst.Content, item)
+		}
+		if len(dst.Content) > len(src.Content) {
+			dst.Content = dst.Content[:len(src.Content)]
+		}
+	}
+}
+
+// sameYAMLScalar reports whether two scalar nodes decode to the same value, e.g. an
+// unquoted date (!!timestamp) and the string the ledger encodes it as
+func sameYAMLScalar(dst, src *yaml.Node) bool {
+	if dst.Value == src.Value && dst.ShortTag() == src.ShortTag() {
+		return true
+	}
+
+	if src.ShortTag() == "!!str" {
+		var value string
+		return dst.Decode(&value) == nil && value == src.Value
+	}
+
+	var dstValue, srcValue any
+	if dst.Decode(&dstValue) != nil || src.Decode(&srcValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(dstValue, srcValue)
+}
+
+// mergeYAMLMapping merges mapping nodes key by key, keeping the key order of dst
+// and appending new keys in the order of src
+func mergeYAMLMapping(dst, src *yaml.Node) {
+	srcValues := make(map[string]*yaml.Node, len(src.Content)/2)
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		srcValues[src.Content[i].Value] = src.Content[i+1]
+	}
+
+	// Update or drop existing keys
+	content := make([]*yaml.Node, 0, len(dst.Content))
+	dstKeys := make(map[string]bool, len(dst.Content)/2)
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		key, value := dst.Content[i], dst.Content[i+1]
+		srcValue, ok := srcValues[key.Value]
+		if !ok {
+			continue
+		}
+
+		mergeYAMLNode(value, srcValue)
+		content = append(content, key, value)
+		dstKeys[key.Value] = true
+	}
+
+	// Append new keys, skipping zero values that were simply omitted from the source
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		if dstKeys[key.Value] || isZeroYAMLNode(value) {
+			continue
+		}
+		content = append(content, key, value)
+	}
+
+	dst.Content = content
+}
+
+// isZeroYAMLNode reports whether a node holds a zero value: 0, false, "", null or an empty collection
+func isZeroYAMLNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!null":
+			return true
+		case "!!int":
+			return node.Value == "0"
+		case "!!bool":
+			return node.Value == "false"
+		case "!!str":
+			return node.Value == ""
+		}
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
+
+// detectYAMLIndent returns the smallest indentation used in the source, defaulting to 4 like yaml.Marshal
+func detectYAMLIndent(source []byte) int {
+	indent := 0
+	for _, line := range strings.Split(string(source), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		n := len(line) - len(trimmed)
+		if n == 0 || trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if indent == 0 || n < indent {
+			indent = n
+		}
+	}
+
+	if indent < 2 {
+		return 4
+	}
+	return indent
+}
@@ -0,0 +1,154 @@
+package v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const annotatedYAML = `# Household ledger
+years:
+  2025:
+    opening_balance: 1000 # USD
+    closing_balance: 1050
+    months:
+      1:
+        opening_balance: 1000
+        closing_balance: 1050
+        accounts:
+          Savings:
+            opening_balance: 400
+            closing_balance: 430
+            entries:
+              - {amount: 30, internal: true, note: "Transfer from Checking", date: "2025-01-30", tag: Transfer}
+          Checking:
+            # main account
+            opening_balance: 600
+            closing_balance: 620
+            entries:
+              - {amount: 50, note: "Salary (January)", date: "2025-01-28", tag: Income}
+              - {amount: -30, internal: true, note: "Transfer to Savings", date: "2025-01-30", tag: Transfer}
+`
+
+func TestUpdateYAML(t *testing.T) {
+	t.Run("unchanged ledger round-trips byte for byte", func(t *testing.T) {
+		ledger := readYAMLString(t, annotatedYAML)
+
+		data, err := updateYAML([]byte(annotatedYAML), ledger)
+		require.NoError(t, err)
+		assert.Equal(t, annotatedYAML, string(data))
+	})
+
+	t.Run("unquoted dates round-trip byte for byte", func(t *testing.T) {
+		source := `years:
+  2025:
+    opening_balance: 0
+    closing_balance: 50
+    months:
+      1:
+        opening_balance: 0
+        closing_balance: 50
+        accounts:
+          Checking:
+            opening_balance: 0
+            closing_balance: 50
+            entries:
+              - amount: 50
+                note: Salary
+                date: 2025-01-28
+              - {amount: 0, note: Fee, date: 2025-01-29}
+`
+		ledger := readYAMLString(t, source)
+		assert.Equal(t, "2025-01-28", ledger.Years[2025].Months[1].Accounts["Checking"].Entries[0].Date)
+
+		data, err := updateYAML([]byte(source), ledger)
+		require.NoError(t, err)
+		assert.Equal(t, source, string(data))
+	})
+
+	t.Run("changed values keep comments, order and style", func(t *testing.T) {
+		ledger := readYAMLString(t, annotatedYAML)
+		ledger.Years[2025].Months[1].Accounts["Checking"].Entries[0].Amount = 70
+		ledger, _ = ledger.Fix()
+
+		data, err := updateYAML([]byte(annotatedYAML), ledger)
+		require.NoError(t, err)
+
+		want := `# Household ledger
+years:
+  2025:
+    opening_balance: 1000 # USD
+    closing_balance: 1070
+    months:
+      1:
+        opening_balance: 1000
+        closing_balance: 1070
+        accounts:
+          Savings:
+            opening_balance: 400
+            closing_balance: 430
+            entries:
+              - {amount: 30, internal: true, note: "Transfer from Checking", date: "2025-01-30", tag: Transfer}
+          Checking:
+            # main account
+            opening_balance: 600
+            closing_balance: 640
+            entries:
+              - {amount: 70, note: "Salary (January)", date: "2025-01-28", tag: Income}
+              - {amount: -30, internal: true, note: "Transfer to Savings", date: "2025-01-30", tag: Transfer}
+`
+		assert.Equal(t, want, string(data))
+	})
+
+	t.Run("added and removed nodes", func(t *testing.T) {
+		ledger := readYAMLString(t, annotatedYAML)
+		checking := ledger.Years[2025].Months[1].Accounts["Checking"]
+		checking.Entries = append(checking.Entries, Entry{Amount: -20, Note: "Groceries", Tag: "Food"})
+		ledger.Years[2025].Months[1].Accounts["Checking"] = checking
+		delete(ledger.Years[2025].Months[1].Accounts, "Savings")
+
+		data, err := updateYAML([]byte(annotatedYAML), ledger)
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(data), "Savings:")
+		assert.Contains(t, string(data), "# main account")
+		assert.Contains(t, string(data), "- {amount: -20, internal: false, note: Groceries, date: \"\", tag: Food}")
+		assert.Equal(t, ledger, readYAMLString(t, string(data)))
+	})
+
+	t.Run("empty source", func(t *testing.T) {
+		ledger := readYAMLString(t, annotatedYAML)
+
+		data, err := updateYAML(nil, ledger)
+		require.NoError(t, err)
+		assert.Equal(t, ledger, readYAMLString(t, string(data)))
+	})
+}
+
+func TestWriteLedger_PreservesYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(annotatedYAML), 0644))
+
+	ledger, err := ReadLedger(path)
+	require.NoError(t, err)
+
+	require.NoError(t, WriteLedger(ledger, path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, annotatedYAML, string(data))
+}
+
+func readYAMLString(t *testing.T, content string) Ledger {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ledger.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	ledger, err := ReadLedger(path)
+	require.NoError(t, err)
+	return ledger
+}
@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"fmt"
 	"sort"
 
 	"github.com/samber/lo"
@@ -16,42 +15,51 @@ type Year struct {
 
 // Validate validates a year according to OLF v2.0 rules
 func (y Year) Validate(yearNum int, prevYear *Year) error {
+	return firstViolation(y.ValidateAll(yearNum, prevYear))
+}
+
+// ValidateAll validates a year and returns every violated rule instead of stopping at the first one
+func (y Year) ValidateAll(yearNum int, prevYear *Year) []Violation {
 	// Y-0: Year key (yearNum) must be a positive integer (yearNum > 0)
 	if yearNum < 1 {
-		return fmt.Errorf("Y-0: year number must be greater than 0 (got: %d)", yearNum)
+		return []Violation{newViolation("Y-0", 0, yearNum, "year number must be greater than 0 (got: %d)", yearNum)}
 	}
 
 	// Y-4: A Year must contain at least one Month entry
 	if len(y.Months) == 0 {
-		return fmt.Errorf("Y-4: year must contain at least one month")
+		return []Violation{newViolation("Y-4", 0, 0, "year must contain at least one month")}
 	}
 
+	var violations []Violation
+
 	// Validate months
 	var prevMonth *Month
 
 	if prevYear != nil {
 		// Y-1: Consecutive years must chain totals: prev.closing_balance = next.opening_balance
 		if y.OpeningBalance != prevYear.ClosingBalance {
-			return fmt.Errorf("Y-1: year opening balance does not equal previous year closing balance (expected: %d, got: %d)",
-				prevYear.ClosingBalance, y.OpeningBalance)
+			violations = append(violations, newViolation("Y-1", prevYear.ClosingBalance, y.OpeningBalance,
+				"year opening balance does not equal previous year closing balance (expected: %d, got: %d)",
+				prevYear.ClosingBalance, y.OpeningBalance))
 		}
 
-		prevYearMonthNums := lo.Keys(prevYear.Months)
-		sort.Ints(prevYearMonthNums)
-
-		lastMonthNum := prevYearMonthNums[len(prevYearMonthNums)-1]
-		prevMonthValue := prevYear.Months[lastMonthNum]
-		prevMonth = &prevMonthValue
+		// The previous year may itself be invalid (Y-4), in which case there is no month to chain from
+		prevYearMonthNums := prevYear.GetMonthNumbers()
+		if len(prevYearMonthNums) > 0 {
+			lastMonthNum := prevYearMonthNums[len(prevYearMonthNums)-1]
+			prevMonthValue := prevYear.Months[lastMonthNum]
+			prevMonth = &prevMonthValue
+		}
 	}
 
-	monthNums := lo.Keys(y.Months)
-	sort.Ints(monthNums)
+	monthNums := y.GetMonthNumbers()
 
 	for _, monthNum := range monthNums {
 		month := y.Months[monthNum]
 
-		if err := month.Validate(yearNum, monthNum, prevMonth); err != nil {
-			return fmt.Errorf("month %d: %w", monthNum, err)
+		for _, violation := range month.ValidateAll(yearNum, monthNum, prevMonth) {
+			violation.Path.Month = monthNum
+			violations = append(violations, violation)
 		}
 
 		prevMonth = &month
@@ -60,18 +68,20 @@ func (y Year) Validate(yearNum int, prevYear *Year) error {
 	// Y-2: Year opening_balance equals first month's opening_balance
 	firstMonth := y.Months[monthNums[0]]
 	if y.OpeningBalance != firstMonth.OpeningBalance {
-		return fmt.Errorf("Y-2: year opening balance does not equal first month opening balance (expected: %d, got: %d)",
-			firstMonth.OpeningBalance, y.OpeningBalance)
+		violations = append(violations, newViolation("Y-2", firstMonth.OpeningBalance, y.OpeningBalance,
+			"year opening balance does not equal first month opening balance (expected: %d, got: %d)",
+			firstMonth.OpeningBalance, y.OpeningBalance))
 	}
 
 	// Y-3: Year closing_balance equals last month's closing_balance
 	lastMonth := y.Months[monthNums[len(monthNums)-1]]
 	if y.ClosingBalance != lastMonth.ClosingBalance {
-		return fmt.Errorf("Y-3: year closing balance does not equal last month closing balance (expected: %d, got: %d)",
-			lastMonth.ClosingBalance, y.ClosingBalance)
+		violations = append(violations, newViolation("Y-3", lastMonth.ClosingBalance, y.ClosingBalance,
+			"year closing balance does not equal last month closing balance (expected: %d, got: %d)",
+			lastMonth.ClosingBalance, y.ClosingBalance))
 	}
 
-	return nil
+	return violations
 }
 
 // Income returns the sum of income from all months
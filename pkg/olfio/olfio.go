@@ -0,0 +1,89 @@
+// Package olfio reads Open Ledger Format files of every version from a path or standard input,
+// taking their encoding from an override, the file extension or the content
+package olfio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Format is the encoding of a ledger file
+type Format string
+
+const (
+	YAML Format = "yaml"
+	JSON Format = "json"
+	TOML Format = "toml"
+)
+
+// StdioPath is the path that reads from standard input and writes to standard output
+const StdioPath = "-"
+
+// ErrUnsupportedFormat is returned when the file extension does not match a supported format
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+// ParseFormat parses a format name: yaml, yml, json or toml
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(name, ".")) {
+	case "yaml", "yml":
+		return YAML, nil
+	case "json":
+		return JSON, nil
+	case "toml":
+		return TOML, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, name)
+	}
+}
+
+// DetectFormat returns the format of a ledger file from its extension.
+// Standard input and output default to YAML.
+func DetectFormat(path string) (Format, error) {
+	if path == StdioPath {
+		return YAML, nil
+	}
+	return ParseFormat(filepath.Ext(path))
+}
+
+// ResolveFormat returns the format override if given, the format detected from the path otherwise
+func ResolveFormat(path string, override Format) (Format, error) {
+	if override != "" {
+		return ParseFormat(string(override))
+	}
+	return DetectFormat(path)
+}
+
+// ReadInput reads a ledger file, or stdin for "-", and returns its content and format.
+// The format is the override if given, detected from the file extension otherwise.
+// Standard input is read as JSON if it starts with '{' and as YAML otherwise.
+func ReadInput(path string, override Format, stdin io.Reader) ([]byte, Format, error) {
+	format, err := ResolveFormat(path, override)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if path != StdioPath {
+		data, err := os.ReadFile(path)
+		return data, format, err
+	}
+
+	data, err := io.ReadAll(stdin)
+	if override == "" {
+		format = sniffFormat(data)
+	}
+	return data, format, err
+}
+
+// sniffFormat guesses the format of ledger data without a file extension:
+// a JSON document starts with an object, anything else is read as YAML
+func sniffFormat(data []byte) Format {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return JSON
+	}
+	return YAML
+}
@@ -0,0 +1,107 @@
+package olfio
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Format
+		wantErr  bool
+	}{
+		{name: "yaml", input: "yaml", expected: YAML},
+		{name: "yml", input: "yml", expected: YAML},
+		{name: "extension", input: ".json", expected: JSON},
+		{name: "upper case", input: "TOML", expected: TOML},
+		{name: "unsupported", input: "xml", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnsupportedFormat)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected Format
+		wantErr  bool
+	}{
+		{name: "yaml", path: "ledger.yaml", expected: YAML},
+		{name: "json", path: "/data/ledger.JSON", expected: JSON},
+		{name: "toml", path: "ledger.toml", expected: TOML},
+		{name: "stdio defaults to yaml", path: StdioPath, expected: YAML},
+		{name: "no extension", path: "ledger", wantErr: true},
+		{name: "unsupported", path: "ledger.txt", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := DetectFormat(tt.path)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnsupportedFormat)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func TestReadInput(t *testing.T) {
+	jsonContent := `{"years": {}}`
+	path := filepath.Join(t.TempDir(), "ledger.json")
+	require.NoError(t, os.WriteFile(path, []byte(jsonContent), 0644))
+
+	tests := []struct {
+		name     string
+		path     string
+		override Format
+		stdin    string
+		want     Format
+		wantErr  error
+	}{
+		{name: "file", path: path, want: JSON},
+		{name: "file with format override", path: path, override: YAML, want: YAML},
+		{name: "stdin sniffed as JSON", path: StdioPath, stdin: jsonContent, want: JSON},
+		{name: "stdin sniffed as YAML", path: StdioPath, stdin: "years: {}\n", want: YAML},
+		{name: "stdin with format override", path: StdioPath, override: YAML, stdin: jsonContent, want: YAML},
+		{name: "unsupported extension", path: "ledger.txt", wantErr: ErrUnsupportedFormat},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.yaml"), wantErr: os.ErrNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, format, err := ReadInput(tt.path, tt.override, strings.NewReader(tt.stdin))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, format)
+			if tt.path == StdioPath {
+				assert.Equal(t, tt.stdin, string(data))
+			} else {
+				assert.Equal(t, jsonContent, string(data))
+			}
+		})
+	}
+}
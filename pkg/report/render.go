@@ -0,0 +1,246 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	v2 "ledger/pkg/ledger/v2"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// Format is a report output format
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatCSV      Format = "csv"
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// ErrUnsupportedFormat is returned for unknown report formats
+var ErrUnsupportedFormat = errors.New("unsupported report format")
+
+// ParseFormat parses a report format name: table, csv, json, markdown (or md) or html
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case FormatTable:
+		return FormatTable, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatMarkdown, "md":
+		return FormatMarkdown, nil
+	case FormatHTML:
+		return FormatHTML, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, name)
+	}
+}
+
+// Render writes the table in the given format. Human-oriented formats (table, Markdown, HTML)
+// format amounts with the currency symbol and separators; CSV and JSON use plain numbers
+// with a "." decimal point so they can be processed further.
+func Render(w io.Writer, t Table, format Format, currency v2.Currency) error {
+	switch format {
+	case FormatTable, FormatMarkdown, FormatHTML:
+		return renderPretty(w, t, format, currency)
+	case FormatCSV:
+		return renderCSV(w, t, currency)
+	case FormatJSON:
+		return renderJSON(w, t, currency)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// RenderTables writes several tables, e.g. a summary followed by a breakdown, in the given format.
+// JSON output is a single array of tables; other formats separate the tables with a blank line.
+func RenderTables(w io.Writer, tables []Table, format Format, currency v2.Currency) error {
+	if format == FormatJSON {
+		docs := make([]jsonTable, len(tables))
+		for i, t := range tables {
+			docs[i] = newJSONTable(t, currency)
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(docs)
+	}
+
+	for i, t := range tables {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if err := Render(w, t, format, currency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plainCurrency returns the currency for machine-readable formats
+func plainCurrency(currency v2.Currency) v2.Currency {
+	currency.DecimalSeparator = "."
+	return currency
+}
+
+// cellText returns the cell formatted for display
+func cellText(cell any, currency v2.Currency) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case Amount:
+		return currency.Format(int(v))
+	case Percent:
+		return strconv.FormatFloat(float64(v), 'f', 1, 64) + "%"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// plainText returns the cell as a plain number for machine-readable formats
+func plainText(cell any, currency v2.Currency) string {
+	switch v := cell.(type) {
+	case Amount:
+		return currency.FormatNumber(int(v))
+	case Percent:
+		return strconv.FormatFloat(float64(v), 'f', 1, 64)
+	default:
+		return cellText(cell, currency)
+	}
+}
+
+func renderPretty(w io.Writer, t Table, format Format, currency v2.Currency) error {
+	tw := table.NewWriter()
+	tw.SetOutputMirror(w)
+	tw.SetTitle(t.Title)
+
+	header := make(table.Row, len(t.Columns))
+	var configs []table.ColumnConfig
+	for i, column := range t.Columns {
+		header[i] = column.Title
+		if column.AlignRight {
+			configs = append(configs, table.ColumnConfig{Number: i + 1, Align: text.AlignRight, AlignFooter: text.AlignRight})
+		}
+	}
+	tw.AppendHeader(header)
+	tw.SetColumnConfigs(configs)
+
+	row := func(cells []any) table.Row {
+		r := make(table.Row, len(cells))
+		for i, cell := range cells {
+			r[i] = cellText(cell, currency)
+		}
+		return r
+	}
+
+	for _, r := range t.Rows {
+		if r.Separator {
+			tw.AppendSeparator()
+		}
+		tw.AppendRow(row(r.Cells))
+	}
+	if t.Footer != nil {
+		tw.AppendFooter(row(t.Footer))
+	}
+
+	switch format {
+	case FormatMarkdown:
+		tw.RenderMarkdown()
+	case FormatHTML:
+		tw.RenderHTML()
+	default:
+		tw.Render()
+	}
+	return nil
+}
+
+func renderCSV(w io.Writer, t Table, currency v2.Currency) error {
+	currency = plainCurrency(currency)
+	cw := csv.NewWriter(w)
+
+	record := func(cells []any) []string {
+		values := make([]string, len(cells))
+		for i, cell := range cells {
+			values[i] = plainText(cell, currency)
+		}
+		return values
+	}
+
+	header := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		header[i] = column.Title
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range t.Rows {
+		if err := cw.Write(record(r.Cells)); err != nil {
+			return err
+		}
+	}
+	if t.Footer != nil {
+		if err := cw.Write(record(t.Footer)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// jsonTable is the JSON representation of a table. Rows are arrays in column order;
+// amounts are numbers in currency units, e.g. 12.5 for 12500 with a scale of 1000.
+type jsonTable struct {
+	Title   string   `json:"title,omitempty"`
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+	Footer  []any    `json:"footer,omitempty"`
+}
+
+func renderJSON(w io.Writer, t Table, currency v2.Currency) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(newJSONTable(t, currency))
+}
+
+func newJSONTable(t Table, currency v2.Currency) jsonTable {
+	currency = plainCurrency(currency)
+
+	values := func(cells []any) []any {
+		result := make([]any, len(cells))
+		for i, cell := range cells {
+			switch cell.(type) {
+			case Amount, Percent:
+				result[i] = json.Number(plainText(cell, currency))
+			default:
+				result[i] = cell
+			}
+		}
+		return result
+	}
+
+	doc := jsonTable{Title: t.Title, Columns: make([]string, len(t.Columns)), Rows: make([][]any, 0, len(t.Rows))}
+	for i, column := range t.Columns {
+		doc.Columns[i] = column.Title
+	}
+	for _, r := range t.Rows {
+		doc.Rows = append(doc.Rows, values(r.Cells))
+	}
+	if t.Footer != nil {
+		doc.Footer = values(t.Footer)
+	}
+
+	return doc
+}
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	v2 "ledger/pkg/ledger/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTable() Table {
+	t := Table{Title: "Monthly"}
+	t.AddColumn("Month")
+	t.AddNumberColumn("Income")
+	t.AddColumn("Note")
+	t.AddNumberColumn("Rate")
+	t.AddRow("2025-01", Amount(1234500), "Salary, bonus", Percent(12.54))
+	t.AddGroupRow("2025-02", Amount(-50), nil, OptionalPercent(nil))
+	t.SetFooter("Total", Amount(1234450), nil, nil)
+	return t
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{input: "table", want: FormatTable},
+		{input: "CSV", want: FormatCSV},
+		{input: "json", want: FormatJSON},
+		{input: "markdown", want: FormatMarkdown},
+		{input: "md", want: FormatMarkdown},
+		{input: "html", want: FormatHTML},
+		{input: "xlsx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnsupportedFormat)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	currency := v2.Currency{Symbol: "$", ThousandsSeparator: ",", DecimalSeparator: ","}
+
+	tests := []struct {
+		name     string
+		format   Format
+		contains []string
+	}{
+		{
+			name:     "table",
+			format:   FormatTable,
+			contains: []string{"Monthly", "| 2025-01 | $1,234,50 | Salary, bonus | 12.5% |", "| TOTAL   | $1,234,45 |"},
+		},
+		{
+			name:     "csv",
+			format:   FormatCSV,
+			contains: []string{"Month,Income,Note,Rate\n", "2025-01,1234.50,\"Salary, bonus\",12.5\n", "2025-02,-0.05,,\n", "Total,1234.45,,\n"},
+		},
+		{
+			name:     "markdown",
+			format:   FormatMarkdown,
+			contains: []string{"| Month | Income | Note | Rate |", "| 2025-01 | $1,234,50 | Salary, bonus | 12.5% |"},
+		},
+		{
+			name:     "html",
+			format:   FormatHTML,
+			contains: []string{"<table", "<td>2025-01</td>", "$1,234,50", "<tfoot>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Render(&buf, testTable(), tt.format, currency))
+			for _, want := range tt.contains {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestRender_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, testTable(), FormatJSON, v2.Currency{Symbol: "$", DecimalSeparator: ","}))
+
+	var doc struct {
+		Title   string   `json:"title"`
+		Columns []string `json:"columns"`
+		Rows    [][]any  `json:"rows"`
+		Footer  []any    `json:"footer"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "Monthly", doc.Title)
+	assert.Equal(t, []string{"Month", "Income", "Note", "Rate"}, doc.Columns)
+	assert.Equal(t, [][]any{{"2025-01", 1234.5, "Salary, bonus", 12.5}, {"2025-02", -0.05, nil, nil}}, doc.Rows)
+	assert.Equal(t, []any{"Total", 1234.45, nil, nil}, doc.Footer)
+}
+
+func TestRenderTables(t *testing.T) {
+	second := Table{Title: "Second"}
+	second.AddColumn("Name")
+	second.AddRow("x")
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderTables(&buf, []Table{testTable(), second}, FormatCSV, v2.Currency{}))
+	assert.Contains(t, buf.String(), "Total,1234.45,,\n\nName\nx\n")
+
+	buf.Reset()
+	require.NoError(t, RenderTables(&buf, []Table{testTable(), second}, FormatJSON, v2.Currency{}))
+
+	var docs []struct {
+		Title string `json:"title"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &docs))
+	require.Len(t, docs, 2)
+	assert.Equal(t, "Second", docs[1].Title)
+}
+
+func TestRender_UnsupportedFormat(t *testing.T) {
+	err := Render(&bytes.Buffer{}, testTable(), "xlsx", v2.Currency{})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
@@ -0,0 +1,73 @@
+// Package report holds a format-independent model of tabular reports and renders it
+// as a terminal table, CSV, JSON, Markdown or HTML, so every format shows the same numbers.
+package report
+
+// Amount is a cell holding a ledger amount, formatted according to the currency when rendered
+type Amount int
+
+// Percent is a cell holding a percentage, e.g. 12.5 for 12.5%
+type Percent float64
+
+// OptionalPercent returns a Percent cell, or an empty cell for nil
+func OptionalPercent(p *float64) any {
+	if p == nil {
+		return nil
+	}
+	return Percent(*p)
+}
+
+// OptionalAmount returns an Amount cell, or an empty cell for nil
+func OptionalAmount(a *int) any {
+	if a == nil {
+		return nil
+	}
+	return Amount(*a)
+}
+
+// Table is a report computed independently of its output format
+type Table struct {
+	Title   string
+	Columns []Column
+	Rows    []Row
+	// Footer holds the totals row, if any
+	Footer []any
+}
+
+// Column describes a column of a table
+type Column struct {
+	Title string
+	// Align right-aligns the column in formats that support alignment, e.g. for numbers
+	AlignRight bool
+}
+
+// Row is a row of cells. Cells hold strings, ints, Amounts, Percents, or nil for empty cells.
+type Row struct {
+	Cells []any
+	// Separator starts a new group of rows, e.g. a new year, in formats that support it
+	Separator bool
+}
+
+// AddColumn appends a text column
+func (t *Table) AddColumn(title string) {
+	t.Columns = append(t.Columns, Column{Title: title})
+}
+
+// AddNumberColumn appends a right-aligned column for ints or Amounts
+func (t *Table) AddNumberColumn(title string) {
+	t.Columns = append(t.Columns, Column{Title: title, AlignRight: true})
+}
+
+// AddRow appends a row of cells
+func (t *Table) AddRow(cells ...any) {
+	t.Rows = append(t.Rows, Row{Cells: cells})
+}
+
+// AddGroupRow appends a row of cells that starts a new group of rows
+func (t *Table) AddGroupRow(cells ...any) {
+	t.Rows = append(t.Rows, Row{Cells: cells, Separator: true})
+}
+
+// SetFooter sets the totals row
+func (t *Table) SetFooter(cells ...any) {
+	t.Footer = cells
+}
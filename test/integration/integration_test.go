@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/require"
 )
@@ -18,6 +19,12 @@ const (
 // Helper function to run CLI command and capture output
 func runCommand(t *testing.T, args ...string) (stdout, stderr string, exitCode int) {
 	t.Helper()
+	return runCommandWithInput(t, "", args...)
+}
+
+// Helper function to run CLI command with the given stdin and capture output
+func runCommandWithInput(t *testing.T, input string, args ...string) (stdout, stderr string, exitCode int) {
+	t.Helper()
 
 	// Get absolute path to binary
 	absPath, err := filepath.Abs(binaryPath)
@@ -31,6 +38,7 @@ func runCommand(t *testing.T, args ...string) (stdout, stderr string, exitCode i
 	}
 
 	cmd := exec.Command(absPath, args...)
+	cmd.Stdin = strings.NewReader(input)
 
 	// Use CombinedOutput to get both stdout and stderr together
 	output, err := cmd.CombinedOutput()
@@ -97,6 +105,22 @@ func TestV1ValidateInvalidStructure(t *testing.T) {
 	}
 }
 
+func TestV1Stdin(t *testing.T) {
+	content, err := os.ReadFile(getTestDataPath("v1/valid.yaml"))
+	require.NoError(t, err)
+
+	stdout, _, exitCode := runCommandWithInput(t, string(content), "v1", "validate", "-")
+	if exitCode != 0 || !strings.Contains(stdout, "✓ Data is valid") {
+		t.Errorf("Expected YAML from stdin to be valid, got exit code %d: %s", exitCode, stdout)
+	}
+
+	// JSON on stdin is detected like for OLF v2.0 files
+	stdout, _, exitCode = runCommandWithInput(t, `{"years": []}`, "v1", "validate", "-")
+	if exitCode != 0 || !strings.Contains(stdout, "✓ Data is valid") {
+		t.Errorf("Expected JSON from stdin to be valid, got exit code %d: %s", exitCode, stdout)
+	}
+}
+
 // V2 Tests
 func TestV2ValidateValid(t *testing.T) {
 	stdout, stderr, exitCode := runCommand(t, "validate", getTestDataPath("v2/valid.yaml"))
@@ -138,6 +162,605 @@ func TestV2ValidateInvalidStructure(t *testing.T) {
 	}
 }
 
+func TestV2ValidateExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		wantCode int
+	}{
+		{name: "valid", file: getTestDataPath("v2/valid.yaml"), wantCode: 0},
+		{name: "invariant violations", file: getTestDataPath("v2/invalid-balance.yaml"), wantCode: 2},
+		{name: "parse error", file: getTestDataPath("v2/invalid-structure.yaml"), wantCode: 3},
+		{name: "missing file", file: getTestDataPath("v2/missing.yaml"), wantCode: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, exitCode := runCommand(t, "validate", tt.file)
+			if exitCode != tt.wantCode {
+				t.Errorf("Expected exit code %d, got %d", tt.wantCode, exitCode)
+			}
+		})
+	}
+}
+
+func TestV2ValidateJSONOutput(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "validate", "--output", "json", getTestDataPath("v2/invalid-balance.yaml"))
+
+	if exitCode != 2 {
+		t.Errorf("Expected exit code 2, got %d", exitCode)
+	}
+
+	for _, want := range []string{`"valid": false`, `"rule": "Y-3"`, `"line": 4`} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %s in JSON output, got: %s", want, stdout)
+		}
+	}
+}
+
+func TestV2ValidateSARIFOutput(t *testing.T) {
+	stdout, _, _ := runCommand(t, "validate", "--output", "sarif", getTestDataPath("v2/invalid-balance.yaml"))
+
+	for _, want := range []string{`"version": "2.1.0"`, `"ruleId": "Y-3"`, `"startLine": 4`,
+		`"uri": "testdata/v2/invalid-balance.yaml"`} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %s in SARIF output, got: %s", want, stdout)
+		}
+	}
+
+	// Files outside the working directory are reported as file: URIs
+	content, err := os.ReadFile(getTestDataPath("v2/invalid-balance.yaml"))
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "ledger.yaml")
+	require.NoError(t, os.WriteFile(file, content, 0644))
+
+	stdout, _, _ = runCommand(t, "validate", "--output", "sarif", file)
+	if want := `"uri": "file://` + filepath.ToSlash(file) + `"`; !strings.Contains(stdout, want) {
+		t.Errorf("Expected %s in SARIF output, got: %s", want, stdout)
+	}
+
+	// A file that cannot be parsed is still reported as a SARIF result
+	stdout, _, exitCode := runCommand(t, "validate", "--output", "sarif", getTestDataPath("v2/invalid-structure.yaml"))
+	if exitCode != 3 {
+		t.Errorf("Expected exit code 3 for unparsable file, got %d", exitCode)
+	}
+	for _, want := range []string{`"version": "2.1.0"`, `"ruleId": "PARSE"`, `"uri": "testdata/v2/invalid-structure.yaml"`} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %s in SARIF output, got: %s", want, stdout)
+		}
+	}
+}
+
+func TestV2Fix(t *testing.T) {
+	content, err := os.ReadFile(getTestDataPath("v2/invalid-balance.yaml"))
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "ledger.yaml")
+	require.NoError(t, os.WriteFile(file, content, 0644))
+
+	// Dry run prints the diff and leaves the file untouched
+	stdout, _, exitCode := runCommand(t, "fix", "--dry-run", file)
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	if !strings.Contains(stdout, "-  closing_balance: 999") || !strings.Contains(stdout, "+  closing_balance: 200") {
+		t.Errorf("Expected diff in output, got: %s", stdout)
+	}
+	unchanged, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, content, unchanged)
+
+	stdout, _, exitCode = runCommand(t, "fix", file)
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+
+	stdout, _, exitCode = runCommand(t, "validate", file)
+	if exitCode != 0 || !strings.Contains(stdout, "✓ Ledger is valid") {
+		t.Errorf("Expected fixed file to be valid, got: %s", stdout)
+	}
+}
+
+func TestV2Stdin(t *testing.T) {
+	content, err := os.ReadFile(getTestDataPath("v2/valid.yaml"))
+	require.NoError(t, err)
+
+	stdout, _, exitCode := runCommandWithInput(t, string(content), "validate", "-")
+	if exitCode != 0 || !strings.Contains(stdout, "✓ Ledger is valid") {
+		t.Errorf("Expected ledger from stdin to be valid, got exit code %d: %s", exitCode, stdout)
+	}
+
+	_, _, exitCode = runCommandWithInput(t, string(content), "validate", "--input-format", "toml", "-")
+	if exitCode != 3 {
+		t.Errorf("Expected exit code 3 for YAML read as TOML, got %d", exitCode)
+	}
+
+	broken, err := os.ReadFile(getTestDataPath("v2/invalid-balance.yaml"))
+	require.NoError(t, err)
+
+	stdout, _, exitCode = runCommandWithInput(t, string(broken), "validate", "-")
+	if exitCode != 2 || !strings.Contains(stdout, "<stdin>:4:") {
+		t.Errorf("Expected violations located in <stdin>, got exit code %d: %s", exitCode, stdout)
+	}
+}
+
+func TestV2FixStdout(t *testing.T) {
+	content, err := os.ReadFile(getTestDataPath("v2/invalid-balance.yaml"))
+	require.NoError(t, err)
+
+	absPath, err := filepath.Abs(binaryPath)
+	require.NoError(t, err)
+
+	// Only the fixed ledger goes to stdout, so it can be piped into another command
+	cmd := exec.Command(absPath, "fix", "--output-format", "json", "-")
+	cmd.Stdin = strings.NewReader(string(content))
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	stdout, _, exitCode := runCommandWithInput(t, string(output), "validate", "--input-format", "json", "-")
+	if exitCode != 0 {
+		t.Errorf("Expected fixed JSON ledger to be valid, got exit code %d: %s", exitCode, stdout)
+	}
+
+	// Without --output-format, a JSON ledger read from stdin is written back as JSON
+	cmd = exec.Command(absPath, "fix", "-")
+	cmd.Stdin = strings.NewReader(string(output))
+	passed, err := cmd.Output()
+	require.NoError(t, err)
+	if !strings.HasPrefix(strings.TrimSpace(string(passed)), "{") {
+		t.Errorf("Expected JSON on stdout for JSON on stdin, got: %s", passed)
+	}
+}
+
+func TestV2ReportCurrency(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "report", "--scale", "1", "--currency", "$", "--thousands-separator", ",",
+		getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	if !strings.Contains(stdout, "$1,000.00") || !strings.Contains(stdout, "-$150.00") {
+		t.Errorf("Expected amounts in whole dollars, got: %s", stdout)
+	}
+
+	_, _, exitCode = runCommand(t, "report", "--scale", "0", getTestDataPath("v2/valid.yaml"))
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1 for invalid scale, got %d", exitCode)
+	}
+}
+
+func TestV2ReportByTag(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "report", "--by", "tag", "--year", "2024", "--scale", "1",
+		getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	for _, want := range []string{"2024-01", "Housing", "-300.00", "Income"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %s in tag report, got: %s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "2023-01") {
+		t.Errorf("Expected only 2024 in tag report, got: %s", stdout)
+	}
+}
+
+func TestV2ReportByTagUntagged(t *testing.T) {
+	ledger := `{"years": {"2025": {"opening_balance": 0, "closing_balance": -50, "months": {"1": {
+		"opening_balance": 0, "closing_balance": -50, "accounts": {"Checking": {
+			"opening_balance": 0, "closing_balance": -50, "entries": [
+				{"amount": -30, "note": "Cash"},
+				{"amount": -20, "note": "Snacks", "tag": "untagged"}]}}}}}}}`
+
+	stdout, _, exitCode := runCommandWithInput(t, ledger, "report", "--by", "tag", "--scale", "1", "--format", "csv", "-")
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	// Entries without a tag stay apart from entries tagged "untagged"
+	for _, want := range []string{"untagged,-20.00,-20.00", "(untagged),-30.00,-30.00"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %s in tag report, got: %s", want, stdout)
+		}
+	}
+}
+
+func TestV2ReportByAccount(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "report", "--by", "account", "--account", "Checking", "--scale", "1",
+		getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	for _, want := range []string{"Checking", "TRANSFERS", "-100.00", "825.00"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %s in account report, got: %s", want, stdout)
+		}
+	}
+
+	stdout, _, exitCode = runCommand(t, "report", "--by", "account", "--account", "Brokerage",
+		getTestDataPath("v2/valid.yaml"))
+	if exitCode == 0 || !strings.Contains(stdout, "account Brokerage not found") {
+		t.Errorf("Expected unknown account error, got exit code %d: %s", exitCode, stdout)
+	}
+}
+
+func TestV2ReportPeriod(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "from and to",
+			args:    []string{"--from", "2023-03", "--to", "2024-01"},
+			want:    []string{"| TOTAL |       | 1150.00 | 400.00 |  -150.00 | 1400.00 |"},
+			notWant: []string{"|  2023 |     2 |", "|  2024 |     2 |"},
+		},
+		{
+			name:    "year",
+			args:    []string{"--year", "2024"},
+			want:    []string{"|  2024 |     1 |", "|  2024 |     2 |"},
+			notWant: []string{"2023"},
+		},
+		{
+			name:    "last year",
+			args:    []string{"--last", "1y"},
+			want:    []string{"|  2023 |     3 |", "|  2024 |     2 |"},
+			notWant: []string{"|  2023 |     2 |"},
+		},
+		{
+			name:    "year to date",
+			args:    []string{"--ytd"},
+			want:    []string{"|  2024 |     1 |"},
+			notWant: []string{"2023"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"report", "--scale", "1", getTestDataPath("v2/valid.yaml")}, tt.args...)
+			stdout, _, exitCode := runCommand(t, args...)
+			if exitCode != 0 {
+				t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("Expected %q in report, got: %s", want, stdout)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(stdout, notWant) {
+					t.Errorf("Expected no %q in report, got: %s", notWant, stdout)
+				}
+			}
+		})
+	}
+
+	_, _, exitCode := runCommand(t, "report", "--from", "2025-13", getTestDataPath("v2/valid.yaml"))
+	if exitCode == 0 {
+		t.Errorf("Expected invalid month to fail")
+	}
+}
+
+func TestV2ReportFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{format: "csv", want: []string{"Year,Month,Opening,Income,Expenses,Closing\n", "2024,2,1400.00,250.00,-150.00,1500.00\n"}},
+		{format: "json", want: []string{`"columns": [`, `1500.00`}},
+		{format: "markdown", want: []string{"| Year | Month | Opening |", "| 2024 | 2 | 1400.00 | 250.00 | -150.00 | 1500.00 |"}},
+		{format: "html", want: []string{"<table", "<td align=\"right\">1500.00</td>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			stdout, _, exitCode := runCommand(t, "report", "--format", tt.format, "--year", "2024", "--scale", "1",
+				getTestDataPath("v2/valid.yaml"))
+			if exitCode != 0 {
+				t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("Expected %q in %s report, got: %s", want, tt.format, stdout)
+				}
+			}
+		})
+	}
+
+	stdout, _, exitCode := runCommand(t, "v1", "report", "--format", "csv", getTestDataPath("v1/valid.yaml"))
+	if exitCode != 0 || !strings.HasPrefix(stdout, "Year,Month,Opening,Income,Expenses,Closing\n") {
+		t.Errorf("Expected v1 CSV report, got exit code %d: %s", exitCode, stdout)
+	}
+
+	_, _, exitCode = runCommand(t, "report", "--format", "xlsx", getTestDataPath("v2/valid.yaml"))
+	if exitCode == 0 {
+		t.Errorf("Expected unsupported format to fail")
+	}
+}
+
+func TestNetWorth(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "networth", "--year", "2024", "--scale", "1", "--format", "csv",
+		getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+
+	for _, want := range []string{
+		"Year,Month,Net Worth,Change,YoY Change,Income,Expenses,Savings Rate,3m Rate,12m Rate\n",
+		"2024,2,1500.00,100.00,350.00,250.00,-150.00,40.0,33.3,53.8\n",
+		"Total,,1500.00,150.00,,450.00,-300.00,33.3,,\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in net worth report, got: %s", want, stdout)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "compare", "--scale", "1", "--format", "csv",
+		getTestDataPath("v2/valid.yaml"), "2023", "2024")
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	for _, want := range []string{
+		"1,250.00,200.00,-50.00,-20.0,-150.00,-150.00,0.00,0.0\n",
+		"3,200.00,missing,,,0.00,missing,,\n",
+		"Total,300.00,450.00,150.00,50.0,-150.00,-300.00,-150.00,-100.0\n",
+		"Housing,-150.00,-300.00,-150.00,-100.0\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in comparison, got: %s", want, stdout)
+		}
+	}
+
+	yoy, _, exitCode := runCommand(t, "report", "--yoy", "--scale", "1", "--format", "csv", getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 || yoy != stdout {
+		t.Errorf("Expected report --yoy to match compare 2023 2024, got exit code %d: %s", exitCode, yoy)
+	}
+
+	stdout, _, exitCode = runCommand(t, "compare", getTestDataPath("v2/valid.yaml"), "2020", "2024")
+	if exitCode == 0 || !strings.Contains(stdout, "year 2020 not found") {
+		t.Errorf("Expected missing year error, got exit code %d: %s", exitCode, stdout)
+	}
+}
+
+func TestChart(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "chart", "--scale", "1", "--width", "40", "--year", "2024",
+		getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+
+	for _, want := range []string{
+		"Net Worth  ▁█\n",
+		"2024-02 │" + strings.Repeat("█", 23) + " 1500.00\n",
+		"Expenses  ▅▅\n",
+		"Spend per Tag\n",
+		"Housing │",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in charts, got: %s", want, stdout)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if n := utf8.RuneCountInString(line); n > 40 {
+			t.Errorf("Expected lines within 40 columns, got %d: %q", n, line)
+		}
+	}
+
+	stdout, _, exitCode = runCommand(t, "chart", "--type", "pie", getTestDataPath("v2/valid.yaml"))
+	if exitCode == 0 {
+		t.Errorf("Expected non-zero exit code for unsupported chart type, got 0. Output: %s", stdout)
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "site")
+
+	stdout, _, exitCode := runCommand(t, "export", "html", "--scale", "1", "--year", "2024",
+		getTestDataPath("v2/valid.yaml"), dir)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	if !strings.Contains(stdout, "✓ Exported 2 months to "+dir) {
+		t.Errorf("Expected export summary, got: %s", stdout)
+	}
+
+	for _, name := range []string{"index.html", "tags.html", "2024-01.html", "2024-02.html"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		if !strings.Contains(string(content), "</html>") {
+			t.Errorf("Expected complete HTML page in %s", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2023-01.html")); !os.IsNotExist(err) {
+		t.Errorf("Expected no page for months outside the period, got: %v", err)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	content, err := os.ReadFile(getTestDataPath("v2/valid.yaml"))
+	require.NoError(t, err)
+
+	budgets := `budgets:
+  - tag: Housing
+    amount: 140
+  - tag: Housing
+    amount: 200
+    from: 2024-02
+  - tag: Housing
+    amount: 250
+    period: year
+`
+	file := filepath.Join(t.TempDir(), "ledger.yaml")
+	require.NoError(t, os.WriteFile(file, append([]byte(budgets), content...), 0644))
+
+	stdout, _, exitCode := runCommand(t, "budget", "--scale", "1", "--format", "csv", file)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	for _, want := range []string{
+		"Tag,Budget,Spent,Remaining,Used,YTD Budget,YTD Spent,YTD Remaining,YTD Used,Status\n",
+		"Housing,200.00,150.00,50.00,75.0,340.00,300.00,40.00,88.2,\n",
+		"Tag,Spent 2024-02,YTD Budget,YTD Spent,YTD Remaining,YTD Used,Status\n",
+		"Housing,150.00,250.00,300.00,-50.00,120.0,OVER\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in budget report, got: %s", want, stdout)
+		}
+	}
+
+	stdout, _, _ = runCommand(t, "budget", "--scale", "1", "--format", "csv", "--month", "2024-01", file)
+	if !strings.Contains(stdout, "Housing,140.00,150.00,-10.00,107.1,140.00,150.00,-10.00,107.1,OVER\n") {
+		t.Errorf("Expected overspent January, got: %s", stdout)
+	}
+
+	stdout, _, exitCode = runCommand(t, "budget", getTestDataPath("v2/valid.yaml"))
+	if exitCode == 0 || !strings.Contains(stdout, "no budgets defined in the ledger") {
+		t.Errorf("Expected error without budgets, got exit code %d: %s", exitCode, stdout)
+	}
+
+	invalid := "budgets:\n  - tag: Housing\n    amount: 0\n"
+	require.NoError(t, os.WriteFile(file, append([]byte(invalid), content...), 0644))
+	stdout, _, exitCode = runCommand(t, "validate", file)
+	if exitCode != 2 || !strings.Contains(stdout, "budgets:\n  - "+file+":2:5: budget 0: B-1:") {
+		t.Errorf("Expected budget violation, got exit code %d: %s", exitCode, stdout)
+	}
+}
+
+func TestForecast(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "forecast", "--scale", "1", "--format", "csv", "--months", "3", "--window", "2",
+		"--scheduled", "2024-04:-100:Insurance", "--by-tag", getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	for _, want := range []string{
+		"2024,2,actual,250.00,-150.00,,100.00,1500.00\n",
+		"2024,3,forecast,225.00,-150.00,0.00,75.00,1575.00\n",
+		"2024,4,forecast,225.00,-150.00,-100.00,-25.00,1550.00\n",
+		"2024,5,forecast,225.00,-150.00,0.00,75.00,1625.00\n",
+		"2024-04,Insurance,-100.00\n",
+		"Housing,-150.00,-450.00\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in forecast, got: %s", want, stdout)
+		}
+	}
+
+	stdout, _, exitCode = runCommand(t, "forecast", "--scheduled", "2023-01:-100", getTestDataPath("v2/valid.yaml"))
+	if exitCode == 0 || !strings.Contains(stdout, "is outside the forecast from 2024-03 to 2025-02") {
+		t.Errorf("Expected scheduled item outside the forecast to fail, got exit code %d: %s", exitCode, stdout)
+	}
+}
+
+func TestRecurring(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "recurring", "--scale", "1", "--format", "csv", getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	for _, want := range []string{
+		"Rent,Housing,Checking,monthly,3,-150.00,-150.00,2024-02-01,0,\"2023-02, 2023-03\",active\n",
+		"Salary,Income,Checking,bimonthly,4,200.00,200.00,2024-02-15,0,,active\n",
+		"Interest,Savings,2024-02,25.00,50.00,25.00,100.0\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in recurring entries, got: %s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "Transfer to Savings") {
+		t.Errorf("Expected series of two months to be left out, got: %s", stdout)
+	}
+
+	stdout, _, exitCode = runCommand(t, "recurring", "--status", "stopped", "--format", "csv", getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 || strings.Contains(stdout, "active") {
+		t.Errorf("Expected no stopped series, got exit code %d: %s", exitCode, stdout)
+	}
+}
+
+func TestAnomalies(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "anomalies", "--scale", "1", "--format", "csv", getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	for _, want := range []string{
+		"2024-01,expenses,,,,0.00,-150.00,-150.00,\n",
+		"2024-01,tag,Housing,,,0.00,-150.00,-150.00,\n",
+		"2024-02,entry,Income,Checking,Salary,50.00,200.00,150.00,300.0\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in anomalies, got: %s", want, stdout)
+		}
+	}
+
+	stdout, _, _ = runCommand(t, "anomalies", "--scale", "1", "--format", "csv", "--last", "1m", "--kind", "entry",
+		"--min-amount", "200", getTestDataPath("v2/valid.yaml"))
+	if strings.Contains(stdout, "2024") {
+		t.Errorf("Expected deviations below --min-amount to be ignored, got: %s", stdout)
+	}
+
+	stdout, _, exitCode = runCommand(t, "anomalies", "--kind", "weekly", getTestDataPath("v2/valid.yaml"))
+	if exitCode == 0 {
+		t.Errorf("Expected non-zero exit code for unsupported kind, got 0. Output: %s", stdout)
+	}
+}
+
+func TestTop(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "top", "--scale", "1", "--format", "csv", "--n", "20", "--period", "2024", getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	for _, want := range []string{
+		"1,2024-01-01,Checking,Housing,Rent,,-150.00\n",
+		"2024-01-25,Checking,Transfer,Transfer to Savings,yes,-50.00\n",
+		"1,2024-01-15,Checking,Income,Salary,,200.00\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in top entries, got: %s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "2023-") {
+		t.Errorf("Expected entries outside --period to be left out, got: %s", stdout)
+	}
+
+	stdout, _, _ = runCommand(t, "top", "--scale", "1", "--format", "csv", "--exclude-internal", "--by-tag", getTestDataPath("v2/valid.yaml"))
+	if strings.Contains(stdout, "Transfer") {
+		t.Errorf("Expected internal entries to be excluded, got: %s", stdout)
+	}
+
+	stdout, _, exitCode = runCommand(t, "top", "--period", "2024-13", getTestDataPath("v2/valid.yaml"))
+	if exitCode == 0 {
+		t.Errorf("Expected non-zero exit code for invalid period, got 0. Output: %s", stdout)
+	}
+}
+
+func TestTransfers(t *testing.T) {
+	stdout, _, exitCode := runCommand(t, "transfers", "--scale", "1", "--format", "csv", getTestDataPath("v2/valid.yaml"))
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d. Output: %s", exitCode, stdout)
+	}
+	for _, want := range []string{
+		"From \\ To,Checking,Savings,Total out\n",
+		"Checking,,100.00,100.00\n",
+		"Checking,,50.00,50.00\n",
+		"Total in,0.00,50.00,50.00\n",
+		"Date,Account,Tag,Note,Amount\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q in transfers, got: %s", want, stdout)
+		}
+	}
+
+	stdout, _, _ = runCommand(t, "transfers", "--scale", "1", "--format", "csv", "--per", "all", getTestDataPath("v2/valid.yaml"))
+	if !strings.Contains(stdout, "Checking,,150.00,150.00\n") {
+		t.Errorf("Expected transfers summed over the whole ledger, got: %s", stdout)
+	}
+
+	stdout, _, exitCode = runCommand(t, "transfers", "--per", "week", getTestDataPath("v2/valid.yaml"))
+	if exitCode == 0 {
+		t.Errorf("Expected non-zero exit code for unsupported --per, got 0. Output: %s", stdout)
+	}
+}
+
 // Migration Test
 func TestV1MigrateToV2(t *testing.T) {
 	// Create temporary output file